@@ -101,7 +101,10 @@ Examples:
 				return fmt.Errorf("create output dir: %w", err)
 			}
 
-			gkBytes := groupKey.Bytes()
+			gkBytes, err := groupKey.Bytes()
+			if err != nil {
+				return fmt.Errorf("encode group key: %w", err)
+			}
 
 			// Write group key info
 			info := keygenOutput{
@@ -76,6 +76,10 @@ type DKGSession struct {
 	// Secret polynomial coefficients: f_i(x) = coeff[0] + coeff[1]*x + ... + coeff[t-1]*x^{t-1}
 	// Each coeff[k] is a vector of ring.Poly (same dimension as secret key).
 	coeffs []structs.Vector[ring.Poly]
+
+	// EntropySource, when set, supplies the Round1 seed in place of
+	// crypto/rand, e.g. to route entropy through an HSM or enclave RNG.
+	EntropySource io.Reader
 }
 
 // NewDKGSession initializes a DKG session for the given party.
@@ -116,11 +120,15 @@ func NewDKGSession(params *Params, partyID, n, t int) (*DKGSession, error) {
 // Round1 generates the party's random polynomial f_i(x) of degree t-1,
 // computes commitments and shares for all other parties.
 //
-// Uses crypto/rand for the per-party Gaussian PRNG seed. For deterministic
-// testing / KAT generation, use Round1WithSeed.
+// Reads the per-party Gaussian PRNG seed from EntropySource, or crypto/rand
+// if it is nil. For deterministic testing / KAT generation, use Round1WithSeed.
 func (d *DKGSession) Round1() (*Round1Output, error) {
+	source := d.EntropySource
+	if source == nil {
+		source = rand.Reader
+	}
 	randKey := make([]byte, sign.KeySize)
-	if _, err := io.ReadFull(rand.Reader, randKey); err != nil {
+	if _, err := io.ReadFull(source, randKey); err != nil {
 		return nil, fmt.Errorf("dkg: random read: %w", err)
 	}
 	return d.Round1WithSeed(randKey)
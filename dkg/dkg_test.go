@@ -4,6 +4,7 @@
 package dkg
 
 import (
+	"bytes"
 	"testing"
 
 	"github.com/luxfi/ringtail/sign"
@@ -96,6 +97,46 @@ func TestDKG_MissingData(t *testing.T) {
 	}
 }
 
+func TestDKG_EntropySource(t *testing.T) {
+	params, err := NewParams()
+	if err != nil {
+		t.Fatalf("NewParams: %v", err)
+	}
+
+	seed := bytes.Repeat([]byte{0x5a}, sign.KeySize)
+	encodings := make([][]byte, 2)
+	for i := range encodings {
+		session, err := NewDKGSession(params, 0, 3, 2)
+		if err != nil {
+			t.Fatalf("NewDKGSession: %v", err)
+		}
+		session.EntropySource = bytes.NewReader(seed)
+		out, err := session.Round1()
+		if err != nil {
+			t.Fatalf("Round1: %v", err)
+		}
+		var buf bytes.Buffer
+		for _, commit := range out.Commits {
+			if _, err := commit.WriteTo(&buf); err != nil {
+				t.Fatalf("WriteTo: %v", err)
+			}
+		}
+		encodings[i] = buf.Bytes()
+	}
+	if !bytes.Equal(encodings[0], encodings[1]) {
+		t.Error("same entropy source produced different Round1 commitments")
+	}
+
+	session, err := NewDKGSession(params, 0, 3, 2)
+	if err != nil {
+		t.Fatalf("NewDKGSession: %v", err)
+	}
+	session.EntropySource = bytes.NewReader(nil)
+	if _, err := session.Round1(); err == nil {
+		t.Error("expected error from exhausted entropy source")
+	}
+}
+
 // runDKG executes a full DKG protocol and verifies the result.
 func runDKG(t *testing.T, n, threshold int) {
 	t.Helper()
@@ -1,7 +1,6 @@
 package main
 
 import (
-	"bufio"
 	"fmt"
 	"log"
 	"math/big"
@@ -112,7 +111,7 @@ func main() {
 				sendWg.Add(1)
 				go func(i int) {
 					defer sendWg.Done()
-					writer := bufio.NewWriter(*comm.GetSock(i))
+					writer := comm.NewWriter(i)
 					comm.SendVector(writer, i, b)
 					comm.SendMatrix(writer, i, A)
 					comm.SendVector(writer, i, skShares[i])
@@ -124,12 +123,12 @@ func main() {
 		sendWg.Wait()
 		genEnd = time.Now()
 	} else {
-		reader := bufio.NewReader(*comm.GetSock(sign.TrustedDealerID))
-		b = comm.RecvVector(reader, sign.TrustedDealerID, sign.M)
-		A = comm.RecvMatrix(reader, sign.TrustedDealerID, sign.M)
-		party.SkShare = comm.RecvVector(reader, sign.TrustedDealerID, sign.N)
-		party.Seed = comm.RecvBytesSliceMap(reader, sign.TrustedDealerID)
-		party.MACKeys = comm.RecvBytesMap(reader, sign.TrustedDealerID)
+		reader := comm.NewReader(sign.TrustedDealerID)
+		b = mustRecv(comm.RecvVector(reader, sign.TrustedDealerID, sign.M))
+		A = mustRecv(comm.RecvMatrix(reader, sign.TrustedDealerID, sign.M))
+		party.SkShare = mustRecv(comm.RecvVector(reader, sign.TrustedDealerID, sign.N))
+		party.Seed = mustRecv(comm.RecvBytesSliceMap(reader, sign.TrustedDealerID))
+		party.MACKeys = mustRecv(comm.RecvBytesMap(reader, sign.TrustedDealerID))
 	}
 
 	time.Sleep(time.Second * 5)
@@ -155,16 +154,16 @@ func main() {
 			round1Wg.Add(2)
 			go func(i int) {
 				defer round1Wg.Done()
-				writer := bufio.NewWriter(*comm.GetSock(i))
+				writer := comm.NewWriter(i)
 				comm.SendMatrix(writer, i, D[partyID])
 				comm.SendBytesMap(writer, i, MACs[partyID])
 			}(i)
 
 			go func(i int) {
 				defer round1Wg.Done()
-				reader := bufio.NewReader(*comm.GetSock(i))
-				D[i] = comm.RecvMatrix(reader, i, sign.M)
-				MACs[i] = comm.RecvBytesMap(reader, i)
+				reader := comm.NewReader(i)
+				D[i] = mustRecv(comm.RecvMatrix(reader, i, sign.M))
+				MACs[i] = mustRecv(comm.RecvBytesMap(reader, i))
 			}(i)
 		}
 	}
@@ -191,14 +190,14 @@ func main() {
 
 	signRound2Start = time.Now()
 	if partyID != sign.CombinerID {
-		writer := bufio.NewWriter(*comm.GetSock(sign.CombinerID))
+		writer := comm.NewWriter(sign.CombinerID)
 		comm.SendVector(writer, sign.CombinerID, z[partyID])
 		signRound2End = time.Now()
 	} else {
 		for i := 0; i < sign.K; i++ {
 			if i != sign.CombinerID {
-				reader := bufio.NewReader(*comm.GetSock(i))
-				z[i] = comm.RecvVector(reader, i, sign.N)
+				reader := comm.NewReader(i)
+				z[i] = mustRecv(comm.RecvVector(reader, i, sign.N))
 			}
 		}
 		combinerReceiveEnd = time.Now()
@@ -240,3 +239,11 @@ func main() {
 		fmt.Printf("Combiner finalize end timestamp: %s\n", combinerFinalizeEnd.Format("15:04:05.000000"))
 	}
 }
+
+// mustRecv aborts the benchmark run when a receive fails or returns the wrong message type.
+func mustRecv[T any](v T, err error) T {
+	if err != nil {
+		log.Fatalf("Receive failed: %v", err)
+	}
+	return v
+}
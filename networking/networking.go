@@ -3,26 +3,69 @@ package networking
 import (
 	"bufio"
 	"encoding/binary"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"net"
 	"sync"
 	"time"
 
+	"github.com/luxfi/ringtail/utils"
+
 	"github.com/luxfi/lattice/v7/ring"
 	"github.com/luxfi/lattice/v7/utils/structs"
 )
 
+var (
+	// ErrIdentityMismatch is returned when a peer announces a rank other than the
+	// one its connection is registered under. The check is unauthenticated; see
+	// ExchangeIdentity.
+	ErrIdentityMismatch = errors.New("peer identity mismatch")
+	// ErrUnexpectedMessageType is returned when a received message carries a
+	// different type tag than the Recv method expects.
+	ErrUnexpectedMessageType = errors.New("unexpected message type")
+)
+
+// MessageType is the one-byte tag that prefixes every message, so a receiver
+// reading the wrong kind of message fails instead of misparsing it.
+type MessageType byte
+
+const (
+	MsgIdentity MessageType = iota + 1
+	MsgBytes
+	MsgVector
+	MsgMatrix
+	MsgBytesSlice
+	MsgBytesMap
+	MsgBytesSliceMap
+)
+
 type Communicator interface {
 	Send(dst int, msg []byte) (int, error)
 	Recv(src int) ([]byte, int, error)
 	Close() error
 }
 
+// DefaultBufferSize is the bufio buffer size NewReader and NewWriter use when
+// P2PComm leaves theirs unset. A Round 1 D matrix is about 800 KB on the wire,
+// so 64 KB moves it in a dozen writes where the bufio default takes some 200.
+const DefaultBufferSize = 64 << 10
+
 type P2PComm struct {
-	Socks map[int]*net.Conn
-	Rank  int
-	mu    sync.Mutex // Added mutex for safe concurrent access
+	Socks           map[int]*net.Conn
+	Rank            int
+	Logger          utils.Logger // Optional; receives send/receive failures and dropped connections
+	ReadBufferSize  int          // Size of NewReader buffers; DefaultBufferSize if zero
+	WriteBufferSize int          // Size of NewWriter buffers; DefaultBufferSize if zero
+	mu              sync.Mutex   // Added mutex for safe concurrent access
+}
+
+func (comm *P2PComm) logger() utils.Logger {
+	if comm.Logger == nil {
+		return utils.NopLogger{}
+	}
+	return comm.Logger
 }
 
 func (comm *P2PComm) SetSock(key int, conn *net.Conn) {
@@ -37,12 +80,91 @@ func (comm *P2PComm) GetSock(key int) *net.Conn {
 	return comm.Socks[key]
 }
 
+// NewReader wraps the connection to peer in a reader of ReadBufferSize bytes.
+func (comm *P2PComm) NewReader(peer int) *bufio.Reader {
+	return bufio.NewReaderSize(*comm.GetSock(peer), bufferSize(comm.ReadBufferSize))
+}
+
+// NewWriter wraps the connection to peer in a writer of WriteBufferSize bytes.
+func (comm *P2PComm) NewWriter(peer int) *bufio.Writer {
+	return bufio.NewWriterSize(*comm.GetSock(peer), bufferSize(comm.WriteBufferSize))
+}
+
+func bufferSize(size int) int {
+	if size <= 0 {
+		return DefaultBufferSize
+	}
+	return size
+}
+
+// ExchangeIdentity announces this party's rank on the connection registered
+// for peer and checks that the other side announces peer. Both ends must call
+// it right after connecting, before any other traffic on the connection.
+//
+// The announcement is not authenticated: anyone who can reach the port can
+// claim any rank. It only catches honest misconfiguration, such as swapped
+// addresses or port offsets. Connections that carry key material must be
+// authenticated by the transport, for example with mutual TLS.
+func (comm *P2PComm) ExchangeIdentity(peer int) error {
+	sock := comm.GetSock(peer)
+	if sock == nil || *sock == nil {
+		return fmt.Errorf("no connection for peer %d", peer)
+	}
+	conn := *sock
+
+	// Write concurrently: the peer is announcing at the same time, and
+	// unbuffered transports block until the other side reads.
+	sent := make(chan error, 1)
+	go func() {
+		announcement := make([]byte, 5)
+		announcement[0] = byte(MsgIdentity)
+		utils.WireByteOrder().PutUint32(announcement[1:], uint32(comm.Rank))
+		_, err := conn.Write(announcement)
+		sent <- err
+	}()
+
+	announced := make([]byte, 5)
+	if _, err := io.ReadFull(conn, announced); err != nil {
+		comm.logger().Warn("receive failed", "rank", comm.Rank, "peer", peer, "err", err)
+		return err
+	}
+	if err := <-sent; err != nil {
+		comm.logger().Warn("send failed", "rank", comm.Rank, "peer", peer, "err", err)
+		return err
+	}
+
+	if MessageType(announced[0]) != MsgIdentity {
+		return fmt.Errorf("%w: got %d, want %d", ErrUnexpectedMessageType, announced[0], MsgIdentity)
+	}
+	if got := int(utils.WireByteOrder().Uint32(announced[1:])); got != peer {
+		comm.logger().Error("peer identity mismatch", "rank", comm.Rank, "peer", peer, "announced", got)
+		return fmt.Errorf("%w: connection for peer %d announced rank %d", ErrIdentityMismatch, peer, got)
+	}
+	return nil
+}
+
+// expectTag reads a message type tag from src and checks it is want.
+func (comm *P2PComm) expectTag(reader *bufio.Reader, src int, want MessageType) error {
+	got, err := reader.ReadByte()
+	if err != nil {
+		comm.logger().Warn("receive failed", "rank", comm.Rank, "peer", src, "err", err)
+		return err
+	}
+	if MessageType(got) != want {
+		comm.logger().Warn("unexpected message type", "rank", comm.Rank, "peer", src, "got", got, "want", byte(want))
+		return fmt.Errorf("%w: got %d, want %d", ErrUnexpectedMessageType, got, want)
+	}
+	return nil
+}
+
 func (comm *P2PComm) SendBytes(writer *bufio.Writer, dst int, msg []byte) (int, error) {
-	length := make([]byte, 4)
-	binary.BigEndian.PutUint32(length, uint32(len(msg)))
+	header := make([]byte, 5)
+	header[0] = byte(MsgBytes)
+	utils.WireByteOrder().PutUint32(header[1:], uint32(len(msg)))
 
-	n, err := writer.Write(length)
+	n, err := writer.Write(header)
 	if err != nil {
+		comm.logger().Warn("send failed", "rank", comm.Rank, "peer", dst, "err", err)
 		return 0, err
 	}
 	totalBytesSent := n
@@ -50,6 +172,7 @@ func (comm *P2PComm) SendBytes(writer *bufio.Writer, dst int, msg []byte) (int,
 	for len(msg) > 0 {
 		n, err = writer.Write(msg)
 		if err != nil {
+			comm.logger().Warn("send failed", "rank", comm.Rank, "peer", dst, "err", err)
 			return 0, err
 		}
 		totalBytesSent += n
@@ -58,6 +181,7 @@ func (comm *P2PComm) SendBytes(writer *bufio.Writer, dst int, msg []byte) (int,
 
 	err = writer.Flush()
 	if err != nil {
+		comm.logger().Warn("send failed", "rank", comm.Rank, "peer", dst, "err", err)
 		return 0, err
 	}
 
@@ -65,22 +189,29 @@ func (comm *P2PComm) SendBytes(writer *bufio.Writer, dst int, msg []byte) (int,
 }
 
 func (comm *P2PComm) Recv(reader *bufio.Reader, src int) ([]byte, int, error) {
+	if err := comm.expectTag(reader, src, MsgBytes); err != nil {
+		return nil, 0, err
+	}
+
 	lengthBuf := make([]byte, 4)
-	totalBytesRead := 0
-	for totalBytesRead < 4 {
-		n, err := reader.Read(lengthBuf[totalBytesRead:])
+	lengthRead := 0
+	for lengthRead < 4 {
+		n, err := reader.Read(lengthBuf[lengthRead:])
 		if err != nil {
-			return nil, totalBytesRead, err
+			comm.logger().Warn("receive failed", "rank", comm.Rank, "peer", src, "err", err)
+			return nil, 1 + lengthRead, err
 		}
-		totalBytesRead += n
+		lengthRead += n
 	}
-	length := binary.BigEndian.Uint32(lengthBuf)
+	totalBytesRead := 1 + lengthRead
+	length := utils.WireByteOrder().Uint32(lengthBuf)
 
 	data := make([]byte, length)
 	bytesRead := 0
 	for bytesRead < int(length) {
 		n, err := reader.Read(data[bytesRead:])
 		if err != nil {
+			comm.logger().Warn("receive failed", "rank", comm.Rank, "peer", src, "err", err)
 			return nil, totalBytesRead, err
 		}
 		bytesRead += n
@@ -93,16 +224,22 @@ func (comm *P2PComm) Recv(reader *bufio.Reader, src int) ([]byte, int, error) {
 func (comm *P2PComm) Close() error {
 	comm.mu.Lock()
 	defer comm.mu.Unlock()
-	for _, sock := range comm.Socks {
+	for peer, sock := range comm.Socks {
 		err := (*sock).Close()
 		if err != nil {
+			comm.logger().Error("closing connection failed", "rank", comm.Rank, "peer", peer, "err", err)
 			return err
 		}
+		comm.logger().Debug("connection closed", "rank", comm.Rank, "peer", peer)
 	}
 	return nil
 }
 
 func (comm *P2PComm) SendVector(writer *bufio.Writer, dst int, msg structs.Vector[ring.Poly]) {
+	if err := writer.WriteByte(byte(MsgVector)); err != nil {
+		log.Fatalf("Failed to write message type: %v", err)
+	}
+
 	if _, err := msg.WriteTo(writer); err != nil {
 		log.Fatalf("Failed to write vector: %v", err)
 	}
@@ -112,15 +249,23 @@ func (comm *P2PComm) SendVector(writer *bufio.Writer, dst int, msg structs.Vecto
 	}
 }
 
-func (comm *P2PComm) RecvVector(reader *bufio.Reader, src int, length int) structs.Vector[ring.Poly] {
+func (comm *P2PComm) RecvVector(reader *bufio.Reader, src int, length int) (structs.Vector[ring.Poly], error) {
+	if err := comm.expectTag(reader, src, MsgVector); err != nil {
+		return nil, err
+	}
+
 	vec := make(structs.Vector[ring.Poly], length)
 	if _, err := vec.ReadFrom(reader); err != nil {
-		log.Fatalf("Failed to read vector: %v", err)
+		return nil, fmt.Errorf("failed to read vector: %w", err)
 	}
-	return vec
+	return vec, nil
 }
 
 func (comm *P2PComm) SendMatrix(writer *bufio.Writer, dst int, msg structs.Matrix[ring.Poly]) {
+	if err := writer.WriteByte(byte(MsgMatrix)); err != nil {
+		log.Fatalf("Failed to write message type: %v", err)
+	}
+
 	if _, err := msg.WriteTo(writer); err != nil {
 		log.Fatalf("Error sending matrix: %v", err)
 	}
@@ -130,23 +275,31 @@ func (comm *P2PComm) SendMatrix(writer *bufio.Writer, dst int, msg structs.Matri
 	}
 }
 
-func (comm *P2PComm) RecvMatrix(reader *bufio.Reader, src int, length int) structs.Matrix[ring.Poly] {
+func (comm *P2PComm) RecvMatrix(reader *bufio.Reader, src int, length int) (structs.Matrix[ring.Poly], error) {
+	if err := comm.expectTag(reader, src, MsgMatrix); err != nil {
+		return nil, err
+	}
+
 	matrix := make(structs.Matrix[ring.Poly], length)
 	if _, err := matrix.ReadFrom(reader); err != nil {
-		log.Fatalf("Failed to read matrix: %v", err)
+		return nil, fmt.Errorf("failed to read matrix: %w", err)
 	}
-	return matrix
+	return matrix, nil
 }
 
 func (comm *P2PComm) SendBytesSlice(writer *bufio.Writer, dst int, data [][]byte) {
+	if err := writer.WriteByte(byte(MsgBytesSlice)); err != nil {
+		log.Fatalf("Failed to write message type: %v", err)
+	}
+
 	numSlices := uint32(len(data))
-	if err := binary.Write(writer, binary.BigEndian, numSlices); err != nil {
+	if err := binary.Write(writer, utils.WireByteOrder(), numSlices); err != nil {
 		log.Fatalf("Failed to write number of slices: %v", err)
 	}
 
 	for _, slice := range data {
 		length := uint32(len(slice))
-		if err := binary.Write(writer, binary.BigEndian, length); err != nil {
+		if err := binary.Write(writer, utils.WireByteOrder(), length); err != nil {
 			log.Fatalf("Failed to write slice length: %v", err)
 		}
 
@@ -164,17 +317,21 @@ func (comm *P2PComm) SendBytesSlice(writer *bufio.Writer, dst int, data [][]byte
 	}
 }
 
-func (comm *P2PComm) RecvBytesSlice(reader *bufio.Reader, src int) [][]byte {
+func (comm *P2PComm) RecvBytesSlice(reader *bufio.Reader, src int) ([][]byte, error) {
+	if err := comm.expectTag(reader, src, MsgBytesSlice); err != nil {
+		return nil, err
+	}
+
 	var numSlices uint32
-	if err := binary.Read(reader, binary.BigEndian, &numSlices); err != nil {
-		log.Fatalf("Failed to read number of slices: %v", err)
+	if err := binary.Read(reader, utils.WireByteOrder(), &numSlices); err != nil {
+		return nil, fmt.Errorf("failed to read number of slices: %w", err)
 	}
 
 	data := make([][]byte, numSlices)
 	for i := uint32(0); i < numSlices; i++ {
 		var length uint32
-		if err := binary.Read(reader, binary.BigEndian, &length); err != nil {
-			log.Fatalf("Failed to read slice length: %v", err)
+		if err := binary.Read(reader, utils.WireByteOrder(), &length); err != nil {
+			return nil, fmt.Errorf("failed to read slice length: %w", err)
 		}
 
 		slice := make([]byte, length)
@@ -182,7 +339,7 @@ func (comm *P2PComm) RecvBytesSlice(reader *bufio.Reader, src int) [][]byte {
 		for bytesRead < int(length) {
 			n, err := reader.Read(slice[bytesRead:])
 			if err != nil {
-				log.Fatalf("Failed to read slice data: %v", err)
+				return nil, fmt.Errorf("failed to read slice data: %w", err)
 			}
 			bytesRead += n
 		}
@@ -190,22 +347,26 @@ func (comm *P2PComm) RecvBytesSlice(reader *bufio.Reader, src int) [][]byte {
 		data[i] = slice
 	}
 
-	return data
+	return data, nil
 }
 
 func (comm *P2PComm) SendBytesMap(writer *bufio.Writer, dst int, data map[int][]byte) {
+	if err := writer.WriteByte(byte(MsgBytesMap)); err != nil {
+		log.Fatalf("Failed to write message type: %v", err)
+	}
+
 	numEntries := uint32(len(data))
-	if err := binary.Write(writer, binary.BigEndian, numEntries); err != nil {
+	if err := binary.Write(writer, utils.WireByteOrder(), numEntries); err != nil {
 		log.Fatalf("Failed to write number of map entries: %v", err)
 	}
 
 	for key, value := range data {
-		if err := binary.Write(writer, binary.BigEndian, int32(key)); err != nil {
+		if err := binary.Write(writer, utils.WireByteOrder(), int32(key)); err != nil {
 			log.Fatalf("Failed to write map key: %v", err)
 		}
 
 		length := uint32(len(value))
-		if err := binary.Write(writer, binary.BigEndian, length); err != nil {
+		if err := binary.Write(writer, utils.WireByteOrder(), length); err != nil {
 			log.Fatalf("Failed to write value length: %v", err)
 		}
 
@@ -223,22 +384,26 @@ func (comm *P2PComm) SendBytesMap(writer *bufio.Writer, dst int, data map[int][]
 	}
 }
 
-func (comm *P2PComm) RecvBytesMap(reader *bufio.Reader, src int) map[int][]byte {
+func (comm *P2PComm) RecvBytesMap(reader *bufio.Reader, src int) (map[int][]byte, error) {
+	if err := comm.expectTag(reader, src, MsgBytesMap); err != nil {
+		return nil, err
+	}
+
 	var numEntries uint32
-	if err := binary.Read(reader, binary.BigEndian, &numEntries); err != nil {
-		log.Fatalf("Failed to read number of map entries: %v", err)
+	if err := binary.Read(reader, utils.WireByteOrder(), &numEntries); err != nil {
+		return nil, fmt.Errorf("failed to read number of map entries: %w", err)
 	}
 
 	data := make(map[int][]byte, numEntries)
 	for i := uint32(0); i < numEntries; i++ {
 		var key int32
-		if err := binary.Read(reader, binary.BigEndian, &key); err != nil {
-			log.Fatalf("Failed to read map key: %v", err)
+		if err := binary.Read(reader, utils.WireByteOrder(), &key); err != nil {
+			return nil, fmt.Errorf("failed to read map key: %w", err)
 		}
 
 		var length uint32
-		if err := binary.Read(reader, binary.BigEndian, &length); err != nil {
-			log.Fatalf("Failed to read value length: %v", err)
+		if err := binary.Read(reader, utils.WireByteOrder(), &length); err != nil {
+			return nil, fmt.Errorf("failed to read value length: %w", err)
 		}
 
 		value := make([]byte, length)
@@ -246,7 +411,7 @@ func (comm *P2PComm) RecvBytesMap(reader *bufio.Reader, src int) map[int][]byte
 		for bytesRead < int(length) {
 			n, err := reader.Read(value[bytesRead:])
 			if err != nil {
-				log.Fatalf("Failed to read value data: %v", err)
+				return nil, fmt.Errorf("failed to read value data: %w", err)
 			}
 			bytesRead += n
 		}
@@ -254,28 +419,32 @@ func (comm *P2PComm) RecvBytesMap(reader *bufio.Reader, src int) map[int][]byte
 		data[int(key)] = value
 	}
 
-	return data
+	return data, nil
 }
 
 func (comm *P2PComm) SendBytesSliceMap(writer *bufio.Writer, dst int, data map[int][][]byte) {
+	if err := writer.WriteByte(byte(MsgBytesSliceMap)); err != nil {
+		log.Fatalf("Failed to write message type: %v", err)
+	}
+
 	numEntries := uint32(len(data))
-	if err := binary.Write(writer, binary.BigEndian, numEntries); err != nil {
+	if err := binary.Write(writer, utils.WireByteOrder(), numEntries); err != nil {
 		log.Fatalf("Failed to write number of map entries: %v", err)
 	}
 
 	for key, value := range data {
-		if err := binary.Write(writer, binary.BigEndian, int32(key)); err != nil {
+		if err := binary.Write(writer, utils.WireByteOrder(), int32(key)); err != nil {
 			log.Fatalf("Failed to write map key: %v", err)
 		}
 
 		numSlices := uint32(len(value))
-		if err := binary.Write(writer, binary.BigEndian, numSlices); err != nil {
+		if err := binary.Write(writer, utils.WireByteOrder(), numSlices); err != nil {
 			log.Fatalf("Failed to write number of slices: %v", err)
 		}
 
 		for _, slice := range value {
 			length := uint32(len(slice))
-			if err := binary.Write(writer, binary.BigEndian, length); err != nil {
+			if err := binary.Write(writer, utils.WireByteOrder(), length); err != nil {
 				log.Fatalf("Failed to write slice length: %v", err)
 			}
 
@@ -294,29 +463,33 @@ func (comm *P2PComm) SendBytesSliceMap(writer *bufio.Writer, dst int, data map[i
 	}
 }
 
-func (comm *P2PComm) RecvBytesSliceMap(reader *bufio.Reader, src int) map[int][][]byte {
+func (comm *P2PComm) RecvBytesSliceMap(reader *bufio.Reader, src int) (map[int][][]byte, error) {
+	if err := comm.expectTag(reader, src, MsgBytesSliceMap); err != nil {
+		return nil, err
+	}
+
 	var numEntries uint32
-	if err := binary.Read(reader, binary.BigEndian, &numEntries); err != nil {
-		log.Fatalf("Failed to read number of map entries: %v", err)
+	if err := binary.Read(reader, utils.WireByteOrder(), &numEntries); err != nil {
+		return nil, fmt.Errorf("failed to read number of map entries: %w", err)
 	}
 
 	data := make(map[int][][]byte, numEntries)
 	for i := uint32(0); i < numEntries; i++ {
 		var key int32
-		if err := binary.Read(reader, binary.BigEndian, &key); err != nil {
-			log.Fatalf("Failed to read map key: %v", err)
+		if err := binary.Read(reader, utils.WireByteOrder(), &key); err != nil {
+			return nil, fmt.Errorf("failed to read map key: %w", err)
 		}
 
 		var numSlices uint32
-		if err := binary.Read(reader, binary.BigEndian, &numSlices); err != nil {
-			log.Fatalf("Failed to read number of slices: %v", err)
+		if err := binary.Read(reader, utils.WireByteOrder(), &numSlices); err != nil {
+			return nil, fmt.Errorf("failed to read number of slices: %w", err)
 		}
 
 		slices := make([][]byte, numSlices)
 		for j := uint32(0); j < numSlices; j++ {
 			var length uint32
-			if err := binary.Read(reader, binary.BigEndian, &length); err != nil {
-				log.Fatalf("Failed to read slice length: %v", err)
+			if err := binary.Read(reader, utils.WireByteOrder(), &length); err != nil {
+				return nil, fmt.Errorf("failed to read slice length: %w", err)
 			}
 
 			slice := make([]byte, length)
@@ -324,7 +497,7 @@ func (comm *P2PComm) RecvBytesSliceMap(reader *bufio.Reader, src int) map[int][]
 			for bytesRead < int(length) {
 				n, err := reader.Read(slice[bytesRead:])
 				if err != nil {
-					log.Fatalf("Failed to read slice data: %v", err)
+					return nil, fmt.Errorf("failed to read slice data: %w", err)
 				}
 				bytesRead += n
 			}
@@ -335,7 +508,7 @@ func (comm *P2PComm) RecvBytesSliceMap(reader *bufio.Reader, src int) map[int][]
 		data[int(key)] = slices
 	}
 
-	return data
+	return data, nil
 }
 
 func ListenTCP(comm *P2PComm, port string, src int) {
@@ -349,10 +522,12 @@ func ListenTCP(comm *P2PComm, port string, src int) {
 		conn, err := l.Accept()
 		if err != nil {
 			log.Println(err)
+			comm.logger().Warn("accept failed", "rank", comm.Rank, "peer", src, "err", err)
 			continue
 		}
 
 		comm.SetSock(src, &conn)
+		comm.logger().Info("connection accepted", "rank", comm.Rank, "peer", src)
 		break
 	}
 }
@@ -386,6 +561,7 @@ func DialTCP(comm *P2PComm, dst int, address string) {
 
 	comm.SetSock(dst, &conn)
 	log.Println("Successfully connected to", address)
+	comm.logger().Info("connection established", "rank", comm.Rank, "peer", dst, "address", address)
 }
 
 func EstablishConnections(wg *sync.WaitGroup, comm *P2PComm, partyID int, totalParties int) {
@@ -406,6 +582,9 @@ func EstablishConnections(wg *sync.WaitGroup, comm *P2PComm, partyID int, totalP
 				} else {
 					DialTCP(comm, otherID, address)
 				}
+				if err := comm.ExchangeIdentity(otherID); err != nil {
+					log.Fatal(err)
+				}
 			}(otherID)
 		}
 	}
@@ -2,7 +2,11 @@ package networking
 
 import (
 	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
 	"net"
+	"sync"
 	"testing"
 	"time"
 
@@ -57,10 +61,11 @@ func TestP2PComm_SendRecvVector(t *testing.T) {
 	// Send and receive in separate goroutines
 	done := make(chan bool)
 	var receivedVector structs.Vector[ring.Poly]
+	var recvErr error
 
 	go func() {
 		reader := bufio.NewReader(server)
-		receivedVector = comm2.RecvVector(reader, 1, len(testVector))
+		receivedVector, recvErr = comm2.RecvVector(reader, 1, len(testVector))
 		done <- true
 	}()
 
@@ -74,7 +79,9 @@ func TestP2PComm_SendRecvVector(t *testing.T) {
 	// Wait for receive to complete
 	select {
 	case <-done:
-		// Success
+		if recvErr != nil {
+			t.Fatalf("receive failed: %v", recvErr)
+		}
 	case <-time.After(1 * time.Second):
 		t.Fatal("Timeout waiting for vector receive")
 	}
@@ -123,10 +130,11 @@ func TestP2PComm_SendRecvMatrix(t *testing.T) {
 	// Send and receive in separate goroutines
 	done := make(chan bool)
 	var receivedMatrix structs.Matrix[ring.Poly]
+	var recvErr error
 
 	go func() {
 		reader := bufio.NewReader(server)
-		receivedMatrix = comm2.RecvMatrix(reader, 1, len(testMatrix))
+		receivedMatrix, recvErr = comm2.RecvMatrix(reader, 1, len(testMatrix))
 		done <- true
 	}()
 
@@ -140,7 +148,9 @@ func TestP2PComm_SendRecvMatrix(t *testing.T) {
 	// Wait for receive to complete
 	select {
 	case <-done:
-		// Success
+		if recvErr != nil {
+			t.Fatalf("receive failed: %v", recvErr)
+		}
 	case <-time.After(1 * time.Second):
 		t.Fatal("Timeout waiting for matrix receive")
 	}
@@ -189,10 +199,11 @@ func TestP2PComm_SendRecvBytes(t *testing.T) {
 	// Send and receive in separate goroutines
 	done := make(chan bool)
 	var receivedBytesSlices [][]byte
+	var recvErr error
 
 	go func() {
 		reader := bufio.NewReader(server)
-		receivedBytesSlices = comm2.RecvBytesSlice(reader, 1)
+		receivedBytesSlices, recvErr = comm2.RecvBytesSlice(reader, 1)
 		done <- true
 	}()
 
@@ -207,7 +218,9 @@ func TestP2PComm_SendRecvBytes(t *testing.T) {
 	// Wait for receive to complete
 	select {
 	case <-done:
-		// Success
+		if recvErr != nil {
+			t.Fatalf("receive failed: %v", recvErr)
+		}
 	case <-time.After(1 * time.Second):
 		t.Fatal("Timeout waiting for bytes receive")
 	}
@@ -253,10 +266,11 @@ func TestP2PComm_SendRecvBytesMap(t *testing.T) {
 	// Send and receive in separate goroutines
 	done := make(chan bool)
 	var receivedBytesMap map[int][]byte
+	var recvErr error
 
 	go func() {
 		reader := bufio.NewReader(server)
-		receivedBytesMap = comm2.RecvBytesMap(reader, 1)
+		receivedBytesMap, recvErr = comm2.RecvBytesMap(reader, 1)
 		done <- true
 	}()
 
@@ -270,7 +284,9 @@ func TestP2PComm_SendRecvBytesMap(t *testing.T) {
 	// Wait for receive to complete
 	select {
 	case <-done:
-		// Success
+		if recvErr != nil {
+			t.Fatalf("receive failed: %v", recvErr)
+		}
 	case <-time.After(1 * time.Second):
 		t.Fatal("Timeout waiting for bytes map receive")
 	}
@@ -313,3 +329,244 @@ func TestP2PComm_Close(t *testing.T) {
 		t.Error("Expected error writing to closed connection")
 	}
 }
+
+func TestP2PComm_WireFormatReference(t *testing.T) {
+	comm := &P2PComm{Rank: 0, Socks: make(map[int]*net.Conn)}
+
+	tests := []struct {
+		name  string
+		send  func(t *testing.T, w *bufio.Writer)
+		check func(t *testing.T, r *bufio.Reader)
+		want  []byte
+	}{
+		{
+			name: "bytes",
+			send: func(t *testing.T, w *bufio.Writer) {
+				if _, err := comm.SendBytes(w, 1, []byte{0xde, 0xad}); err != nil {
+					t.Fatal(err)
+				}
+			},
+			check: func(t *testing.T, r *bufio.Reader) {
+				data, _, err := comm.Recv(r, 1)
+				if err != nil || !bytes.Equal(data, []byte{0xde, 0xad}) {
+					t.Errorf("Recv() = %x, %v", data, err)
+				}
+			},
+			want: []byte{byte(MsgBytes), 0, 0, 0, 2, 0xde, 0xad},
+		},
+		{
+			name: "bytes slice",
+			send: func(t *testing.T, w *bufio.Writer) { comm.SendBytesSlice(w, 1, [][]byte{{0x01}, {0x02, 0x03}}) },
+			check: func(t *testing.T, r *bufio.Reader) {
+				data, err := comm.RecvBytesSlice(r, 1)
+				if err != nil || len(data) != 2 || !bytes.Equal(data[0], []byte{0x01}) || !bytes.Equal(data[1], []byte{0x02, 0x03}) {
+					t.Errorf("RecvBytesSlice() = %x, %v", data, err)
+				}
+			},
+			want: []byte{byte(MsgBytesSlice), 0, 0, 0, 2, 0, 0, 0, 1, 0x01, 0, 0, 0, 2, 0x02, 0x03},
+		},
+		{
+			name: "bytes map",
+			send: func(t *testing.T, w *bufio.Writer) { comm.SendBytesMap(w, 1, map[int][]byte{7: {0xaa, 0xbb}}) },
+			check: func(t *testing.T, r *bufio.Reader) {
+				data, err := comm.RecvBytesMap(r, 1)
+				if err != nil || len(data) != 1 || !bytes.Equal(data[7], []byte{0xaa, 0xbb}) {
+					t.Errorf("RecvBytesMap() = %x, %v", data, err)
+				}
+			},
+			want: []byte{byte(MsgBytesMap), 0, 0, 0, 1, 0, 0, 0, 7, 0, 0, 0, 2, 0xaa, 0xbb},
+		},
+		{
+			name: "bytes slice map",
+			send: func(t *testing.T, w *bufio.Writer) { comm.SendBytesSliceMap(w, 1, map[int][][]byte{3: {{0x0f}}}) },
+			check: func(t *testing.T, r *bufio.Reader) {
+				data, err := comm.RecvBytesSliceMap(r, 1)
+				if err != nil || len(data) != 1 || len(data[3]) != 1 || !bytes.Equal(data[3][0], []byte{0x0f}) {
+					t.Errorf("RecvBytesSliceMap() = %x, %v", data, err)
+				}
+			},
+			want: []byte{byte(MsgBytesSliceMap), 0, 0, 0, 1, 0, 0, 0, 3, 0, 0, 0, 1, 0, 0, 0, 1, 0x0f},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			tt.send(t, bufio.NewWriter(buf))
+			if !bytes.Equal(buf.Bytes(), tt.want) {
+				t.Fatalf("encoded %x, want %x", buf.Bytes(), tt.want)
+			}
+			tt.check(t, bufio.NewReader(bytes.NewReader(tt.want)))
+		})
+	}
+}
+
+type recordingLogger struct {
+	mu   sync.Mutex
+	msgs []string
+}
+
+func (l *recordingLogger) record(msg string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.msgs = append(l.msgs, msg)
+}
+
+func (l *recordingLogger) Debug(msg string, _ ...any) { l.record(msg) }
+func (l *recordingLogger) Info(msg string, _ ...any)  { l.record(msg) }
+func (l *recordingLogger) Warn(msg string, _ ...any)  { l.record(msg) }
+func (l *recordingLogger) Error(msg string, _ ...any) { l.record(msg) }
+
+func TestP2PComm_LogsDroppedConnection(t *testing.T) {
+	server, client := net.Pipe()
+	logger := &recordingLogger{}
+	comm := &P2PComm{
+		Rank:   1,
+		Socks:  map[int]*net.Conn{2: &client},
+		Logger: logger,
+	}
+
+	server.Close()
+	if _, _, err := comm.Recv(bufio.NewReader(client), 2); err == nil {
+		t.Fatal("expected an error receiving from a closed peer")
+	}
+	client.Close()
+
+	logger.mu.Lock()
+	defer logger.mu.Unlock()
+	if len(logger.msgs) != 1 || logger.msgs[0] != "receive failed" {
+		t.Errorf("logged %q, want a single receive failure", logger.msgs)
+	}
+}
+
+func TestP2PComm_ExchangeIdentity(t *testing.T) {
+	tests := []struct {
+		name       string
+		clientRank int // rank announced by the client, registered as peer 1
+		wantErr    bool
+	}{
+		{name: "matching identities", clientRank: 1},
+		{name: "swapped identities", clientRank: 3, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, client := net.Pipe()
+			defer server.Close()
+			defer client.Close()
+
+			comm1 := &P2PComm{Rank: tt.clientRank, Socks: map[int]*net.Conn{2: &client}}
+			comm2 := &P2PComm{Rank: 2, Socks: map[int]*net.Conn{1: &server}}
+
+			errs := make(chan error, 1)
+			go func() { errs <- comm1.ExchangeIdentity(2) }()
+
+			err := comm2.ExchangeIdentity(1)
+			if clientErr := <-errs; clientErr != nil {
+				t.Fatalf("client side failed: %v", clientErr)
+			}
+			if tt.wantErr {
+				if !errors.Is(err, ErrIdentityMismatch) {
+					t.Fatalf("expected ErrIdentityMismatch, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ExchangeIdentity failed: %v", err)
+			}
+		})
+	}
+}
+
+func TestP2PComm_UnexpectedMessageType(t *testing.T) {
+	comm := &P2PComm{Rank: 0, Socks: make(map[int]*net.Conn)}
+
+	r, _ := ring.NewRing(256, []uint64{8380417})
+	prng, _ := sampling.NewPRNG()
+	sampler := ring.NewUniformSampler(prng, r)
+	matrix := structs.Matrix[ring.Poly]{{sampler.ReadNew(), sampler.ReadNew()}}
+
+	buf := new(bytes.Buffer)
+	comm.SendMatrix(bufio.NewWriter(buf), 1, matrix)
+
+	if _, err := comm.RecvVector(bufio.NewReader(bytes.NewReader(buf.Bytes())), 1, 2); !errors.Is(err, ErrUnexpectedMessageType) {
+		t.Fatalf("RecvVector on a matrix: expected ErrUnexpectedMessageType, got %v", err)
+	}
+
+	received, err := comm.RecvMatrix(bufio.NewReader(bytes.NewReader(buf.Bytes())), 1, 1)
+	if err != nil {
+		t.Fatalf("RecvMatrix failed: %v", err)
+	}
+	if !r.Equal(received[0][1], matrix[0][1]) {
+		t.Error("matrix mismatch after a tagged round trip")
+	}
+}
+
+func TestP2PComm_BufferSizes(t *testing.T) {
+	server, client := net.Pipe()
+	defer server.Close()
+	defer client.Close()
+
+	comm := &P2PComm{Rank: 1, Socks: map[int]*net.Conn{2: &client}}
+	if got := comm.NewReader(2).Size(); got != DefaultBufferSize {
+		t.Errorf("default reader size %d, want %d", got, DefaultBufferSize)
+	}
+	if got := comm.NewWriter(2).Size(); got != DefaultBufferSize {
+		t.Errorf("default writer size %d, want %d", got, DefaultBufferSize)
+	}
+
+	comm.ReadBufferSize, comm.WriteBufferSize = 8<<10, 1<<20
+	if got := comm.NewReader(2).Size(); got != 8<<10 {
+		t.Errorf("reader size %d, want %d", got, 8<<10)
+	}
+	if got := comm.NewWriter(2).Size(); got != 1<<20 {
+		t.Errorf("writer size %d, want %d", got, 1<<20)
+	}
+}
+
+func BenchmarkSendRecvDMatrix(b *testing.B) {
+	r, _ := ring.NewRing(256, []uint64{8380417})
+	prng, _ := sampling.NewPRNG()
+	sampler := ring.NewUniformSampler(prng, r)
+	// A Round 1 D matrix has sign.M rows and sign.Dbar+1 columns.
+	D := make(structs.Matrix[ring.Poly], 8)
+	for i := range D {
+		D[i] = make(structs.Vector[ring.Poly], 49)
+		for j := range D[i] {
+			D[i][j] = sampler.ReadNew()
+		}
+	}
+
+	for _, size := range []int{4 << 10, 16 << 10, DefaultBufferSize, 1 << 20} {
+		b.Run(fmt.Sprintf("%dKB", size>>10), func(b *testing.B) {
+			server, client := net.Pipe()
+			defer server.Close()
+			defer client.Close()
+
+			sender := &P2PComm{Rank: 1, Socks: map[int]*net.Conn{2: &client}, WriteBufferSize: size}
+			receiver := &P2PComm{Rank: 2, Socks: map[int]*net.Conn{1: &server}, ReadBufferSize: size}
+			writer := sender.NewWriter(2)
+			reader := receiver.NewReader(1)
+
+			errs := make(chan error, 1)
+			go func() {
+				for i := 0; i < b.N; i++ {
+					if _, err := receiver.RecvMatrix(reader, 1, len(D)); err != nil {
+						errs <- err
+						return
+					}
+				}
+				errs <- nil
+			}()
+
+			b.SetBytes(int64(D.BinarySize()))
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				sender.SendMatrix(writer, 2, D)
+			}
+			if err := <-errs; err != nil {
+				b.Fatal(err)
+			}
+		})
+	}
+}
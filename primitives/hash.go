@@ -3,7 +3,9 @@ package primitives
 import (
 	"bytes"
 	"encoding/binary"
+	"io"
 	"log"
+	"sort"
 
 	"github.com/luxfi/ringtail/utils"
 
@@ -57,43 +59,69 @@ func PRNGKeyForRound(skShare structs.Vector[ring.Poly], sid int64) []byte {
 	if _, err := hasher.Write([]byte(tag)); err != nil {
 		log.Fatalf("Error writing tag: %v\n", err)
 	}
-	if err := binary.Write(hasher, binary.BigEndian, sid); err != nil {
+	if err := binary.Write(hasher, utils.TranscriptByteOrder(), sid); err != nil {
 		log.Fatalf("Error writing sid: %v\n", err)
 	}
 	skHash := hasher.Sum(nil)
 	return skHash[:keySize]
 }
 
-// GenerateMAC generates a MAC for a given TildeD matrix and mask
+// DeriveMACKey derives the MAC key shared by partyA and partyB from a common seed.
+// The pair is ordered as (min, max) before hashing, so both parties derive the
+// identical key regardless of which of them calls it.
+//
+// Layout: BLAKE3(sharedSeed || "RingtailMACKeyV1" || be64(min) || be64(max)).
+func DeriveMACKey(sharedSeed []byte, partyA, partyB int) []byte {
+	lo, hi := min(partyA, partyB), max(partyA, partyB)
+
+	hasher := blake3.New()
+	if _, err := hasher.Write(sharedSeed); err != nil {
+		log.Fatalf("Error writing seed: %v\n", err)
+	}
+	const tag = "RingtailMACKeyV1"
+	if _, err := hasher.Write([]byte(tag)); err != nil {
+		log.Fatalf("Error writing tag: %v\n", err)
+	}
+	for _, id := range []int64{int64(lo), int64(hi)} {
+		if err := binary.Write(hasher, utils.TranscriptByteOrder(), id); err != nil {
+			log.Fatalf("Error writing party ID: %v\n", err)
+		}
+	}
+	key := hasher.Sum(nil)
+	return key[:keySize]
+}
+
+// GenerateMAC generates a MAC for a given TildeD matrix and mask. T is absorbed in canonical order.
 func GenerateMAC(TildeD structs.Matrix[ring.Poly], MACKey []byte, partyID int, sid int, T []int, otherParty int, verify bool) []byte {
 	hasher := blake3.New()
 	buf := new(bytes.Buffer)
+	T = CanonicalSignerSet(T)
 
 	if verify {
-		if err := binary.Write(buf, binary.BigEndian, int64(otherParty)); err != nil {
+		if err := binary.Write(buf, utils.TranscriptByteOrder(), int64(otherParty)); err != nil {
 			log.Fatalf("Error writing otherParty: %v\n", err)
 		}
 	} else {
-		if err := binary.Write(buf, binary.BigEndian, int64(partyID)); err != nil {
+		if err := binary.Write(buf, utils.TranscriptByteOrder(), int64(partyID)); err != nil {
 			log.Fatalf("Error writing partyID: %v\n", err)
 		}
 	}
 
-	if err := binary.Write(buf, binary.BigEndian, MACKey); err != nil {
+	if err := binary.Write(buf, utils.TranscriptByteOrder(), MACKey); err != nil {
 		log.Fatalf("Error writing MACKey: %v\n", err)
 	}
 	if _, err := TildeD.WriteTo(buf); err != nil {
 		log.Fatalf("Error writing TildeD: %v\n", err)
 	}
-	if err := binary.Write(buf, binary.BigEndian, int64(sid)); err != nil {
+	if err := binary.Write(buf, utils.TranscriptByteOrder(), int64(sid)); err != nil {
 		log.Fatalf("Error writing sid: %v\n", err)
 	}
 	// Write T array length and elements
-	if err := binary.Write(buf, binary.BigEndian, int32(len(T))); err != nil {
+	if err := binary.Write(buf, utils.TranscriptByteOrder(), int32(len(T))); err != nil {
 		log.Fatalf("Error writing T length: %v\n", err)
 	}
 	for _, t := range T {
-		if err := binary.Write(buf, binary.BigEndian, int32(t)); err != nil {
+		if err := binary.Write(buf, utils.TranscriptByteOrder(), int32(t)); err != nil {
 			log.Fatalf("Error writing T element: %v\n", err)
 		}
 	}
@@ -107,61 +135,62 @@ func GenerateMAC(TildeD structs.Matrix[ring.Poly], MACKey []byte, partyID int, s
 
 // Hashes parameters to a Gaussian distribution
 func GaussianHash(r *ring.Ring, hash []byte, mu string, sigmaU float64, boundU float64, length int) structs.Vector[ring.Poly] {
-	hasher := blake3.New()
-	buf := new(bytes.Buffer)
-
-	if err := binary.Write(buf, binary.BigEndian, hash); err != nil {
-		log.Fatalf("Error writing hash: %v\n", err)
-	}
-	if _, err := buf.WriteString(mu); err != nil {
-		log.Fatalf("Error writing mu: %v\n", err)
-	}
+	out := utils.InitializeVector(r, length)
+	GaussianHashInto(r, hash, mu, sigmaU, boundU, out)
+	return out
+}
 
-	if _, err := hasher.Write(buf.Bytes()); err != nil {
-		log.Fatalf("Error writing to hasher: %v\n", err)
-	}
+// GaussianHashInto is GaussianHash writing into out, whose length sets the
+// number of samples. Hot paths reuse out across calls.
+func GaussianHashInto(r *ring.Ring, hash []byte, mu string, sigmaU float64, boundU float64, out structs.Vector[ring.Poly]) {
+	hasher := blake3.New()
+	_, _ = hasher.Write(hash)
+	_, _ = io.WriteString(hasher, mu)
 	hashOutput := hasher.Sum(nil)
 
 	prng, _ := sampling.NewKeyedPRNG(hashOutput[:keySize])
 	gaussianParams := ring.DiscreteGaussian{Sigma: sigmaU, Bound: boundU}
 	hashGaussianSampler := ring.NewGaussianSampler(prng, r, gaussianParams, false)
 
-	return utils.SamplePolyVector(r, length, hashGaussianSampler, true, true)
+	utils.SamplePolyVectorInto(r, out, hashGaussianSampler, true, true)
 }
 
 // PRF generates pseudorandom ring elements
 func PRF(r *ring.Ring, sd_ij []byte, PRFKey []byte, mu string, hash []byte, n int) structs.Vector[ring.Poly] {
-	hasher := blake3.New()
-	buf := new(bytes.Buffer)
-
-	if err := binary.Write(buf, binary.BigEndian, PRFKey); err != nil {
-		log.Fatalf("Error writing PRFKey: %v\n", err)
-	}
-	if err := binary.Write(buf, binary.BigEndian, sd_ij); err != nil {
-		log.Fatalf("Error writing sd_ij: %v\n", err)
-	}
-	if err := binary.Write(buf, binary.BigEndian, hash); err != nil {
-		log.Fatalf("Error writing hash: %v\n", err)
-	}
-	if _, err := buf.WriteString(mu); err != nil {
-		log.Fatalf("Error writing mu: %v\n", err)
-	}
+	mask := utils.InitializeVector(r, n)
+	PRFInto(r, sd_ij, PRFKey, mu, hash, mask)
+	return mask
+}
 
-	if _, err := hasher.Write(buf.Bytes()); err != nil {
-		log.Fatalf("Error writing to hasher: %v\n", err)
-	}
+// PRFInto is PRF writing into out, whose length sets the number of ring
+// elements. Hot paths reuse out across calls.
+func PRFInto(r *ring.Ring, sd_ij []byte, PRFKey []byte, mu string, hash []byte, out structs.Vector[ring.Poly]) {
+	hasher := blake3.New()
+	_, _ = hasher.Write(PRFKey)
+	_, _ = hasher.Write(sd_ij)
+	_, _ = hasher.Write(hash)
+	_, _ = io.WriteString(hasher, mu)
 	hashOutput := hasher.Sum(nil)
 
 	prng, _ := sampling.NewKeyedPRNG(hashOutput[:keySize])
 	PRFUniformSampler := ring.NewUniformSampler(prng, r)
-	mask := utils.SamplePolyVector(r, n, PRFUniformSampler, true, true)
-	return mask
+	utils.SamplePolyVectorInto(r, out, PRFUniformSampler, true, true)
 }
 
-// Hashes precomputable values
+// CanonicalSignerSet returns a sorted copy of T. Transcripts absorb the signer
+// set in this order, so parties listing the same set differently still agree.
+func CanonicalSignerSet(T []int) []int {
+	canonical := append([]int(nil), T...)
+	sort.Ints(canonical)
+	return canonical
+}
+
+// Hashes precomputable values. T is absorbed in canonical order and D in
+// ascending party order.
 func Hash(A structs.Matrix[ring.Poly], b structs.Vector[ring.Poly], D map[int]structs.Matrix[ring.Poly], sid int, T []int) []byte {
 	hasher := blake3.New()
 	buf := new(bytes.Buffer)
+	T = CanonicalSignerSet(T)
 
 	if _, err := A.WriteTo(buf); err != nil {
 		log.Fatalf("Error writing matrix A: %v\n", err)
@@ -171,20 +200,25 @@ func Hash(A structs.Matrix[ring.Poly], b structs.Vector[ring.Poly], D map[int]st
 		log.Fatalf("Error writing vector b: %v\n", err)
 	}
 
-	if err := binary.Write(buf, binary.BigEndian, int64(sid)); err != nil {
+	if err := binary.Write(buf, utils.TranscriptByteOrder(), int64(sid)); err != nil {
 		log.Fatalf("Error writing sid: %v\n", err)
 	}
 	// Write T array length and elements
-	if err := binary.Write(buf, binary.BigEndian, int32(len(T))); err != nil {
+	if err := binary.Write(buf, utils.TranscriptByteOrder(), int32(len(T))); err != nil {
 		log.Fatalf("Error writing T length: %v\n", err)
 	}
 	for _, t := range T {
-		if err := binary.Write(buf, binary.BigEndian, int32(t)); err != nil {
+		if err := binary.Write(buf, utils.TranscriptByteOrder(), int32(t)); err != nil {
 			log.Fatalf("Error writing T element: %v\n", err)
 		}
 	}
 
-	for i := 0; i < len(D); i++ {
+	parties := make([]int, 0, len(D))
+	for i := range D {
+		parties = append(parties, i)
+	}
+	sort.Ints(parties)
+	for _, i := range parties {
 		if _, err := D[i].WriteTo(buf); err != nil {
 			log.Fatalf("Error writing matrix D_i: %v\n", err)
 		}
@@ -199,7 +233,12 @@ func Hash(A structs.Matrix[ring.Poly], b structs.Vector[ring.Poly], D map[int]st
 
 // Hashes to low norm ring elements
 func LowNormHash(r *ring.Ring, A structs.Matrix[ring.Poly], b structs.Vector[ring.Poly], h structs.Vector[ring.Poly], mu string, kappa int) ring.Poly {
-	hasher := blake3.New()
+	return LowNormHashWithPrefix(r, LowNormHashPrefix(A, b), h, mu, kappa)
+}
+
+// LowNormHashPrefix serializes the public-key part of the LowNormHash input.
+// It depends only on A and b, so a verifier can compute it once per key.
+func LowNormHashPrefix(A structs.Matrix[ring.Poly], b structs.Vector[ring.Poly]) []byte {
 	buf := new(bytes.Buffer)
 
 	if _, err := A.WriteTo(buf); err != nil {
@@ -210,11 +249,22 @@ func LowNormHash(r *ring.Ring, A structs.Matrix[ring.Poly], b structs.Vector[rin
 		log.Fatalf("Error writing vector b: %v\n", err)
 	}
 
+	return buf.Bytes()
+}
+
+// LowNormHashWithPrefix is LowNormHash with A and b given as a prefix from LowNormHashPrefix
+func LowNormHashWithPrefix(r *ring.Ring, prefix []byte, h structs.Vector[ring.Poly], mu string, kappa int) ring.Poly {
+	hasher := blake3.New()
+	if _, err := hasher.Write(prefix); err != nil {
+		log.Fatalf("Error writing to hasher: %v\n", err)
+	}
+	buf := new(bytes.Buffer)
+
 	if _, err := h.WriteTo(buf); err != nil {
 		log.Fatalf("Error writing vector h: %v\n", err)
 	}
 
-	if err := binary.Write(buf, binary.BigEndian, []byte(mu)); err != nil {
+	if err := binary.Write(buf, utils.TranscriptByteOrder(), []byte(mu)); err != nil {
 		log.Fatalf("Error writing mu: %v\n", err)
 	}
 
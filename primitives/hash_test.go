@@ -1,6 +1,7 @@
 package primitives
 
 import (
+	"bytes"
 	"testing"
 
 	"github.com/luxfi/ringtail/utils"
@@ -8,6 +9,7 @@ import (
 	"github.com/luxfi/lattice/v7/ring"
 	"github.com/luxfi/lattice/v7/utils/sampling"
 	"github.com/luxfi/lattice/v7/utils/structs"
+	"github.com/zeebo/blake3"
 )
 
 func TestPRNGKey(t *testing.T) {
@@ -77,6 +79,38 @@ func TestGenerateMAC(t *testing.T) {
 	}
 }
 
+func TestDeriveMACKey(t *testing.T) {
+	seed := []byte("shared-seed-32-bytes-long-------")
+
+	tests := []struct {
+		name   string
+		a, b   int
+		otherA int
+		otherB int
+	}{
+		{name: "adjacent parties", a: 0, b: 1, otherA: 0, otherB: 2},
+		{name: "distant parties", a: 3, b: 17, otherA: 3, otherB: 16},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key := DeriveMACKey(seed, tt.a, tt.b)
+			if len(key) != keySize {
+				t.Fatalf("DeriveMACKey() returned %d bytes, want %d", len(key), keySize)
+			}
+			if !bytes.Equal(key, DeriveMACKey(seed, tt.b, tt.a)) {
+				t.Error("DeriveMACKey() depends on the order of the parties")
+			}
+			if bytes.Equal(key, DeriveMACKey(seed, tt.otherA, tt.otherB)) {
+				t.Error("DeriveMACKey() returned the same key for a different pair")
+			}
+			if bytes.Equal(key, DeriveMACKey([]byte("other-seed"), tt.a, tt.b)) {
+				t.Error("DeriveMACKey() ignores the shared seed")
+			}
+		})
+	}
+}
+
 func TestGaussianHash(t *testing.T) {
 	r, err := ring.NewRing(256, []uint64{8380417})
 	if err != nil {
@@ -133,6 +167,83 @@ func TestPRF(t *testing.T) {
 	}
 }
 
+func TestHashIntoMatchesAllocating(t *testing.T) {
+	r, err := ring.NewRing(256, []uint64{8380417})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hash := []byte("test-hash-32-bytes-long---------")
+	PRFKey := []byte("prf-key-32-bytes-long-----------")
+	sd_ij := []byte("seed-data")
+	mu := "test-message"
+
+	// Reference samplers keyed as before PRFInto and GaussianHashInto existed
+	keyed := func(parts ...[]byte) *sampling.KeyedPRNG {
+		hasher := blake3.New()
+		for _, part := range parts {
+			hasher.Write(part)
+		}
+		prng, _ := sampling.NewKeyedPRNG(hasher.Sum(nil)[:keySize])
+		return prng
+	}
+
+	tests := []struct {
+		name string
+		want structs.Vector[ring.Poly]
+		into func(out structs.Vector[ring.Poly])
+	}{
+		{
+			name: "GaussianHash",
+			want: utils.SamplePolyVector(r, 5, ring.NewGaussianSampler(keyed(hash, []byte(mu)), r,
+				ring.DiscreteGaussian{Sigma: 1.0, Bound: 6.0}, false), true, true),
+			into: func(out structs.Vector[ring.Poly]) { GaussianHashInto(r, hash, mu, 1.0, 6.0, out) },
+		},
+		{
+			name: "PRF",
+			want: utils.SamplePolyVector(r, 5, ring.NewUniformSampler(keyed(PRFKey, sd_ij, hash, []byte(mu)), r), true, true),
+			into: func(out structs.Vector[ring.Poly]) { PRFInto(r, sd_ij, PRFKey, mu, hash, out) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Reuse a buffer holding unrelated values
+			out := utils.SamplePolyVector(r, 5, ring.NewUniformSampler(keyed([]byte("junk")), r), false, false)
+			for round := 0; round < 2; round++ {
+				tt.into(out)
+				for i := range out {
+					if !r.Equal(out[i], tt.want[i]) {
+						t.Fatalf("round %d: element %d differs from the allocating sampler", round, i)
+					}
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkGaussianHash(b *testing.B) {
+	r, err := ring.NewRing(256, []uint64{0x1000000004A01})
+	if err != nil {
+		b.Fatal(err)
+	}
+	hash := []byte("test-hash-32-bytes-long---------")
+
+	b.Run("GaussianHash", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			GaussianHash(r, hash, "bench", 163961331.5239387, 2*163961331.5239387, 48)
+		}
+	})
+	b.Run("GaussianHashInto", func(b *testing.B) {
+		b.ReportAllocs()
+		out := utils.InitializeVector(r, 48)
+		for i := 0; i < b.N; i++ {
+			GaussianHashInto(r, hash, "bench", 163961331.5239387, 2*163961331.5239387, out)
+		}
+	})
+}
+
 func TestHash(t *testing.T) {
 	r, err := ring.NewRing(256, []uint64{8380417})
 	if err != nil {
@@ -228,6 +339,12 @@ func TestLowNormHash(t *testing.T) {
 	if !r.Equal(result, result2) {
 		t.Error("LowNormHash() is not deterministic")
 	}
+
+	// The prefix split must not change the digest
+	result3 := LowNormHashWithPrefix(r, LowNormHashPrefix(A, b), h, mu, kappa)
+	if !r.Equal(result, result3) {
+		t.Error("LowNormHashWithPrefix() differs from LowNormHash()")
+	}
 }
 
 func TestGenerateRandomSeed(t *testing.T) {
@@ -254,3 +371,44 @@ func TestGenerateRandomSeed(t *testing.T) {
 		t.Error("GenerateRandomSeed() appears to be deterministic")
 	}
 }
+
+func TestTranscriptSignerOrder(t *testing.T) {
+	r, err := ring.NewRing(256, []uint64{8380417})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prng, _ := sampling.NewPRNG()
+	sampler := ring.NewUniformSampler(prng, r)
+
+	A := createTestMatrix(sampler, 2, 2)
+	b := createTestSecret(r, sampler, 2)
+	D := map[int]structs.Matrix[ring.Poly]{
+		1: createTestMatrix(sampler, 2, 2),
+		4: createTestMatrix(sampler, 2, 2),
+		7: createTestMatrix(sampler, 2, 2),
+	}
+	MACKey := []byte("test-mac-key-32-bytes-long------")
+
+	canonical := []int{1, 4, 7}
+	wantHash := Hash(A, b, D, 1, canonical)
+	wantMAC := GenerateMAC(D[1], MACKey, 1, 1, canonical, 4, false)
+
+	for _, T := range [][]int{{7, 1, 4}, {4, 7, 1}, {7, 4, 1}} {
+		if !bytes.Equal(Hash(A, b, D, 1, T), wantHash) {
+			t.Errorf("Hash() depends on the order of T %v", T)
+		}
+		if !bytes.Equal(GenerateMAC(D[1], MACKey, 1, 1, T, 4, false), wantMAC) {
+			t.Errorf("GenerateMAC() depends on the order of T %v", T)
+		}
+	}
+	if got := CanonicalSignerSet([]int{7, 1, 4}); got[0] != 1 || got[1] != 4 || got[2] != 7 {
+		t.Errorf("CanonicalSignerSet() = %v, want %v", got, canonical)
+	}
+
+	// Every D is bound, not only those keyed below len(D).
+	D[7] = createTestMatrix(sampler, 2, 2)
+	if bytes.Equal(Hash(A, b, D, 1, canonical), wantHash) {
+		t.Error("Hash() ignores D of the highest party")
+	}
+}
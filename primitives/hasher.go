@@ -0,0 +1,67 @@
+package primitives
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/luxfi/ringtail/utils"
+
+	"github.com/luxfi/lattice/v7/ring"
+	"github.com/luxfi/lattice/v7/utils/structs"
+	"github.com/zeebo/blake3"
+)
+
+// PolyHasher absorbs transcript elements into a BLAKE3 state as they become
+// available, writing each one straight to the hasher. Absorbing the inputs of
+// Hash in the same order yields the same digest as Hash.
+type PolyHasher struct {
+	hasher *blake3.Hasher
+}
+
+// NewPolyHasher returns an empty PolyHasher
+func NewPolyHasher() *PolyHasher {
+	return &PolyHasher{hasher: blake3.New()}
+}
+
+// AbsorbPoly absorbs p, which must be a polynomial of r
+func (h *PolyHasher) AbsorbPoly(r *ring.Ring, p ring.Poly) error {
+	if p.N() != r.N() {
+		return fmt.Errorf("polynomial has degree %d, ring has degree %d", p.N(), r.N())
+	}
+	if _, err := p.WriteTo(h.hasher); err != nil {
+		return fmt.Errorf("absorbing polynomial: %w", err)
+	}
+	return nil
+}
+
+// AbsorbVector absorbs v with the encoding of Vector.WriteTo
+func (h *PolyHasher) AbsorbVector(v structs.Vector[ring.Poly]) error {
+	if _, err := v.WriteTo(h.hasher); err != nil {
+		return fmt.Errorf("absorbing vector: %w", err)
+	}
+	return nil
+}
+
+// AbsorbMatrix absorbs m with the encoding of Matrix.WriteTo
+func (h *PolyHasher) AbsorbMatrix(m structs.Matrix[ring.Poly]) error {
+	if _, err := m.WriteTo(h.hasher); err != nil {
+		return fmt.Errorf("absorbing matrix: %w", err)
+	}
+	return nil
+}
+
+// AbsorbInt64 absorbs v in transcript byte order
+func (h *PolyHasher) AbsorbInt64(v int64) error {
+	return binary.Write(h.hasher, utils.TranscriptByteOrder(), v)
+}
+
+// AbsorbInt32 absorbs v in transcript byte order
+func (h *PolyHasher) AbsorbInt32(v int32) error {
+	return binary.Write(h.hasher, utils.TranscriptByteOrder(), v)
+}
+
+// Sum returns the digest of everything absorbed so far, truncated to the key size.
+// It does not change the hasher state, so absorption may continue afterwards.
+func (h *PolyHasher) Sum() []byte {
+	return h.hasher.Sum(nil)[:keySize]
+}
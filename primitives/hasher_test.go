@@ -0,0 +1,90 @@
+package primitives
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/luxfi/lattice/v7/ring"
+	"github.com/luxfi/lattice/v7/utils/sampling"
+	"github.com/luxfi/lattice/v7/utils/structs"
+)
+
+func TestPolyHasherMatchesHash(t *testing.T) {
+	r, err := ring.NewRing(256, []uint64{8380417})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prng, _ := sampling.NewPRNG()
+	sampler := ring.NewUniformSampler(prng, r)
+
+	A := createTestMatrix(sampler, 2, 3)
+	b := createTestSecret(r, sampler, 2)
+	D := map[int]structs.Matrix[ring.Poly]{
+		0: createTestMatrix(sampler, 2, 2),
+		1: createTestMatrix(sampler, 2, 2),
+		2: createTestMatrix(sampler, 2, 2),
+	}
+	sid := 7
+	T := []int{0, 1, 2}
+
+	h := NewPolyHasher()
+	absorb := func(err error) {
+		t.Helper()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	absorb(h.AbsorbMatrix(A))
+	absorb(h.AbsorbVector(b))
+	absorb(h.AbsorbInt64(int64(sid)))
+	absorb(h.AbsorbInt32(int32(len(T))))
+	for _, j := range T {
+		absorb(h.AbsorbInt32(int32(j)))
+	}
+	for i := 0; i < len(D); i++ {
+		absorb(h.AbsorbMatrix(D[i]))
+	}
+
+	if got, want := h.Sum(), Hash(A, b, D, sid, T); !bytes.Equal(got, want) {
+		t.Errorf("PolyHasher digest %x, Hash digest %x", got, want)
+	}
+}
+
+func TestPolyHasherAbsorbPoly(t *testing.T) {
+	r, err := ring.NewRing(256, []uint64{8380417})
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := ring.NewRing(512, []uint64{8380417})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h := NewPolyHasher()
+	empty := h.Sum()
+	if err := h.AbsorbPoly(r, other.NewPoly()); err == nil {
+		t.Error("AbsorbPoly accepted a polynomial of another ring")
+	}
+	if !bytes.Equal(h.Sum(), empty) {
+		t.Error("a rejected polynomial changed the hasher state")
+	}
+
+	if err := h.AbsorbPoly(r, r.NewPoly()); err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Equal(h.Sum(), empty) {
+		t.Error("AbsorbPoly did not change the digest")
+	}
+}
+
+func createTestMatrix(sampler ring.Sampler, rows, cols int) structs.Matrix[ring.Poly] {
+	m := make(structs.Matrix[ring.Poly], rows)
+	for i := range m {
+		m[i] = make(structs.Vector[ring.Poly], cols)
+		for j := range m[i] {
+			m[i][j] = sampler.ReadNew()
+		}
+	}
+	return m
+}
@@ -2,6 +2,9 @@ package primitives
 
 import (
 	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
 	"math/big"
 
 	"github.com/luxfi/ringtail/utils"
@@ -10,8 +13,27 @@ import (
 	"github.com/luxfi/lattice/v7/utils/structs"
 )
 
+// ErrInconsistentShares is returned by VerifyShareConsistency when the shares
+// do not all lie on one sharing polynomial of the threshold's degree.
+var ErrInconsistentShares = errors.New("shares reconstruct different secrets")
+
 // ShamirSecretSharing shares each coefficient of a vector of ring.Poly across k parties using (t, k)-threshold Shamir secret sharing.
 func ShamirSecretSharingGeneral(r *ring.Ring, s []ring.Poly, t, k int) map[int]structs.Vector[ring.Poly] {
+	return ShamirSecretSharingGeneralFrom(r, s, t, k, rand.Reader)
+}
+
+// ShamirSecretSharingGeneralFrom is ShamirSecretSharingGeneral drawing the random polynomial
+// coefficients from rng, so a keyed stream makes the sharing reproducible. Share i is the
+// evaluation at x = i+1, matching ComputeLagrangeCoefficients.
+func ShamirSecretSharingGeneralFrom(r *ring.Ring, s []ring.Poly, t, k int, rng io.Reader) map[int]structs.Vector[ring.Poly] {
+	shares, _ := shamirSharing(r, s, t, k, rng)
+	return shares
+}
+
+// shamirSharing implements ShamirSecretSharingGeneralFrom, also returning the
+// coefficients a_0 = s, a_1, ..., a_{t-1} of the sharing polynomial in the
+// coefficient domain.
+func shamirSharing(r *ring.Ring, s []ring.Poly, t, k int, rng io.Reader) (map[int]structs.Vector[ring.Poly], []structs.Vector[ring.Poly]) {
 
 	degree := r.N() // Number of coefficients in each ring.Poly
 	q := r.Modulus()
@@ -26,6 +48,11 @@ func ShamirSecretSharingGeneral(r *ring.Ring, s []ring.Poly, t, k int) map[int]s
 		}
 	}
 
+	coefficients := make([]structs.Vector[ring.Poly], t)
+	for i := range coefficients {
+		coefficients[i] = utils.InitializeVector(r, len(s))
+	}
+
 	for polyIndex, poly := range s {
 		coeffs := make([]*big.Int, degree)
 		r.PolyToBigint(poly, 1, coeffs)
@@ -34,9 +61,12 @@ func ShamirSecretSharingGeneral(r *ring.Ring, s []ring.Poly, t, k int) map[int]s
 			polyCoeffs := make([]*big.Int, t)
 			polyCoeffs[0] = secret
 			for i := 1; i < t; i++ {
-				randomCoeff, _ := rand.Int(rand.Reader, q)
+				randomCoeff, _ := rand.Int(rng, q)
 				polyCoeffs[i] = randomCoeff
 			}
+			for i, coeff := range polyCoeffs {
+				coefficients[i][polyIndex].Coeffs[0][coeffIndex] = new(big.Int).Mod(coeff, q).Uint64()
+			}
 
 			for i := 1; i <= k; i++ {
 				x := big.NewInt(int64(i))
@@ -59,7 +89,7 @@ func ShamirSecretSharingGeneral(r *ring.Ring, s []ring.Poly, t, k int) map[int]s
 		}
 	}
 
-	return shares
+	return shares, coefficients
 }
 
 // ShamirSecretSharing shares each coefficient of a vector of ring.Poly across k parties using (t, k)-threshold Shamir secret sharing. This optimized implementation only works when t = k.
@@ -119,24 +149,39 @@ func ShamirSecretSharing(r *ring.Ring, s []ring.Poly, k int, lambdas []ring.Poly
 }
 
 // ComputeLagrangeCoefficients computes the Lagrange coefficients for interpolation based on the indices of available shares.
+//
+// The coefficient of x_i = T[i]+1 at zero is prod_{j!=i} (-x_j) / prod_{j!=i} (x_i - x_j).
+// With P = prod_j (-x_j) this is P / ((-x_i) * prod_{j!=i} (x_i - x_j)), so all the
+// denominators are inverted together with a single ModInverse (Montgomery's trick).
 func ComputeLagrangeCoefficients(r *ring.Ring, T []int, modulus *big.Int) []ring.Poly {
-	lagrangeCoefficients := make([]ring.Poly, len(T))
-	for i := 0; i < len(T); i++ {
-		xi := big.NewInt(int64(T[i] + 1))
-		numerator := big.NewInt(1)
-		denominator := big.NewInt(1)
-		for j := 0; j < len(T); j++ {
+	xs := make([]*big.Int, len(T))
+	for i, t := range T {
+		xs[i] = big.NewInt(int64(t + 1))
+	}
+
+	P := big.NewInt(1)
+	temp := new(big.Int)
+	for _, x := range xs {
+		P.Mul(P, temp.Neg(x))
+		P.Mod(P, modulus)
+	}
+
+	denominators := make([]*big.Int, len(xs))
+	for i, xi := range xs {
+		denominator := new(big.Int).Neg(xi)
+		for j, xj := range xs {
 			if i != j {
-				xj := big.NewInt(int64(T[j] + 1))
-				numerator.Mul(numerator, new(big.Int).Neg(xj))
-				numerator.Mod(numerator, modulus)
-				temp := new(big.Int).Sub(xi, xj)
-				denominator.Mul(denominator, temp)
+				denominator.Mul(denominator, temp.Sub(xi, xj))
 				denominator.Mod(denominator, modulus)
 			}
 		}
-		denomInv := new(big.Int).ModInverse(denominator, modulus)
-		coeff := new(big.Int).Mul(numerator, denomInv)
+		denominators[i] = denominator.Mod(denominator, modulus)
+	}
+	denomInvs := batchModInverse(denominators, modulus)
+
+	lagrangeCoefficients := make([]ring.Poly, len(T))
+	for i := range xs {
+		coeff := new(big.Int).Mul(P, denomInvs[i])
 		coeff.Mod(coeff, modulus)
 		lagrangePoly := r.NewPoly()
 		r.SetCoefficientsBigint([]*big.Int{coeff}, lagrangePoly)
@@ -144,3 +189,87 @@ func ComputeLagrangeCoefficients(r *ring.Ring, T []int, modulus *big.Int) []ring
 	}
 	return lagrangeCoefficients
 }
+
+// batchModInverse inverts every element of vals modulo modulus using one ModInverse
+// and 3(n-1) multiplications. All elements must be invertible.
+func batchModInverse(vals []*big.Int, modulus *big.Int) []*big.Int {
+	if len(vals) == 0 {
+		return nil
+	}
+
+	prefix := make([]*big.Int, len(vals))
+	prefix[0] = new(big.Int).Set(vals[0])
+	for i := 1; i < len(vals); i++ {
+		prefix[i] = new(big.Int).Mul(prefix[i-1], vals[i])
+		prefix[i].Mod(prefix[i], modulus)
+	}
+
+	inv := new(big.Int).ModInverse(prefix[len(vals)-1], modulus)
+	invs := make([]*big.Int, len(vals))
+	for i := len(vals) - 1; i > 0; i-- {
+		invs[i] = new(big.Int).Mul(inv, prefix[i-1])
+		invs[i].Mod(invs[i], modulus)
+		inv.Mul(inv, vals[i])
+		inv.Mod(inv, modulus)
+	}
+	invs[0] = inv
+	return invs
+}
+
+// VerifyShareConsistency checks that the coefficient-domain shares of a
+// (t, n) sharing, shares[i] held by party i, reconstruct the same secret from
+// every window of t consecutive parties, wrapping around. Each share is left
+// out of one window and used by another, so a single bad share or a sharing
+// polynomial of degree t or more makes two windows disagree. With t = n there
+// is a single subset and nothing to compare.
+func VerifyShareConsistency(r *ring.Ring, shares []structs.Vector[ring.Poly], t int) error {
+	n := len(shares)
+	if t < 1 || t > n {
+		return fmt.Errorf("threshold %d out of range for %d shares", t, n)
+	}
+	nttShares := make([]structs.Vector[ring.Poly], n)
+	for i, share := range shares {
+		if len(share) != len(shares[0]) {
+			return fmt.Errorf("%w: share %d has %d polynomials, share 0 has %d", ErrInconsistentShares, i, len(share), len(shares[0]))
+		}
+		nttShares[i] = copyVector(share)
+		utils.ConvertVectorToNTT(r, nttShares[i])
+	}
+
+	windows := n
+	if t == n {
+		windows = 1
+	}
+	var want structs.Vector[ring.Poly]
+	for start := 0; start < windows; start++ {
+		subset := make([]int, t)
+		for k := range subset {
+			subset[k] = (start + k) % n
+		}
+		secret := reconstructSecret(r, nttShares, subset)
+		if want == nil {
+			want = secret
+			continue
+		}
+		for j := range secret {
+			if !r.Equal(secret[j], want[j]) {
+				return fmt.Errorf("%w: parties %v disagree with the first %d", ErrInconsistentShares, subset, t)
+			}
+		}
+	}
+	return nil
+}
+
+// reconstructSecret interpolates the NTT-domain shares of the parties in
+// subset at zero, returning the secret in the NTT domain.
+func reconstructSecret(r *ring.Ring, shares []structs.Vector[ring.Poly], subset []int) structs.Vector[ring.Poly] {
+	secret := utils.InitializeVector(r, len(shares[0]))
+	term := utils.InitializeVector(r, len(shares[0]))
+	for k, lambda := range ComputeLagrangeCoefficients(r, subset, r.Modulus()) {
+		r.NTT(lambda, lambda)
+		r.MForm(lambda, lambda)
+		utils.VectorPolyMul(r, shares[subset[k]], lambda, term)
+		utils.VectorAdd(r, secret, term, secret)
+	}
+	return secret
+}
@@ -1,6 +1,8 @@
 package primitives
 
 import (
+	"errors"
+	"fmt"
 	"math/big"
 	"testing"
 
@@ -52,6 +54,101 @@ func TestComputeLagrangeCoefficients(t *testing.T) {
 	}
 }
 
+func TestComputeLagrangeCoefficientsMatchesNaive(t *testing.T) {
+	for _, q := range []uint64{8380417, 0x1000000004A01} {
+		r, err := ring.NewRing(256, []uint64{q})
+		if err != nil {
+			t.Fatal(err)
+		}
+		modulus := new(big.Int).SetUint64(q)
+
+		tests := []struct {
+			name    string
+			parties []int
+		}{
+			{name: "single party", parties: []int{0}},
+			{name: "two parties", parties: []int{0, 1}},
+			{name: "sparse subset", parties: []int{1, 4, 7, 9}},
+			{name: "unsorted subset", parties: []int{9, 2, 5}},
+			{name: "128 parties", parties: partyRange(128)},
+		}
+
+		for _, tt := range tests {
+			t.Run(fmt.Sprintf("q=%d/%s", q, tt.name), func(t *testing.T) {
+				got := ComputeLagrangeCoefficients(r, tt.parties, modulus)
+				want := computeLagrangeCoefficientsNaive(r, tt.parties, modulus)
+
+				if len(got) != len(want) {
+					t.Fatalf("got %d coefficients, want %d", len(got), len(want))
+				}
+				for i := range want {
+					if !r.Equal(got[i], want[i]) {
+						t.Errorf("coefficient %d differs from the naive computation", i)
+					}
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkComputeLagrangeCoefficients(b *testing.B) {
+	r, err := ring.NewRing(256, []uint64{0x1000000004A01})
+	if err != nil {
+		b.Fatal(err)
+	}
+	modulus := r.Modulus()
+
+	for _, n := range []int{8, 32, 64, 128} {
+		parties := partyRange(n)
+		b.Run(fmt.Sprintf("batched/n=%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				ComputeLagrangeCoefficients(r, parties, modulus)
+			}
+		})
+		b.Run(fmt.Sprintf("naive/n=%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				computeLagrangeCoefficientsNaive(r, parties, modulus)
+			}
+		})
+	}
+}
+
+// computeLagrangeCoefficientsNaive is the reference implementation with one
+// modular inversion per coefficient.
+func computeLagrangeCoefficientsNaive(r *ring.Ring, T []int, modulus *big.Int) []ring.Poly {
+	lagrangeCoefficients := make([]ring.Poly, len(T))
+	for i := 0; i < len(T); i++ {
+		xi := big.NewInt(int64(T[i] + 1))
+		numerator := big.NewInt(1)
+		denominator := big.NewInt(1)
+		for j := 0; j < len(T); j++ {
+			if i != j {
+				xj := big.NewInt(int64(T[j] + 1))
+				numerator.Mul(numerator, new(big.Int).Neg(xj))
+				numerator.Mod(numerator, modulus)
+				temp := new(big.Int).Sub(xi, xj)
+				denominator.Mul(denominator, temp)
+				denominator.Mod(denominator, modulus)
+			}
+		}
+		denomInv := new(big.Int).ModInverse(denominator, modulus)
+		coeff := new(big.Int).Mul(numerator, denomInv)
+		coeff.Mod(coeff, modulus)
+		lagrangePoly := r.NewPoly()
+		r.SetCoefficientsBigint([]*big.Int{coeff}, lagrangePoly)
+		lagrangeCoefficients[i] = lagrangePoly
+	}
+	return lagrangeCoefficients
+}
+
+func partyRange(n int) []int {
+	parties := make([]int, n)
+	for i := range parties {
+		parties[i] = i
+	}
+	return parties
+}
+
 func TestShamirSecretSharing(t *testing.T) {
 	r, err := ring.NewRing(256, []uint64{8380417})
 	if err != nil {
@@ -177,6 +274,55 @@ func TestShamirSecretSharingGeneral(t *testing.T) {
 	}
 }
 
+func TestVerifyShareConsistency(t *testing.T) {
+	r, err := ring.NewRing(256, []uint64{8380417})
+	if err != nil {
+		t.Fatal(err)
+	}
+	prng, _ := sampling.NewPRNG()
+	sampler := ring.NewUniformSampler(prng, r)
+	secret := createTestSecret(r, sampler, 3)
+
+	deal := func(threshold, k int) []structs.Vector[ring.Poly] {
+		shareMap := ShamirSecretSharingGeneral(r, secret, threshold, k)
+		shares := make([]structs.Vector[ring.Poly], k)
+		for i := range shares {
+			shares[i] = shareMap[i]
+		}
+		return shares
+	}
+
+	tests := []struct {
+		name    string
+		shares  []structs.Vector[ring.Poly]
+		t       int
+		tamper  bool
+		wantErr bool
+	}{
+		{name: "2-of-3", shares: deal(2, 3), t: 2},
+		{name: "3-of-5", shares: deal(3, 5), t: 3},
+		{name: "3-of-5 tampered", shares: deal(3, 5), t: 3, tamper: true, wantErr: true},
+		{name: "degree too high", shares: deal(4, 5), t: 3, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.tamper {
+				p := tt.shares[2][1].Coeffs[0]
+				p[5] = (p[5] + 1) % 8380417
+			}
+			err := VerifyShareConsistency(r, tt.shares, tt.t)
+			if tt.wantErr != errors.Is(err, ErrInconsistentShares) {
+				t.Errorf("VerifyShareConsistency() = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if err := VerifyShareConsistency(r, deal(2, 3), 4); err == nil {
+		t.Error("expected an error for a threshold above the share count")
+	}
+}
+
 // Helper function to create test secrets
 func createTestSecret(r *ring.Ring, sampler ring.Sampler, size int) structs.Vector[ring.Poly] {
 	secret := make(structs.Vector[ring.Poly], size)
@@ -0,0 +1,112 @@
+package primitives
+
+import (
+	"io"
+	"math/big"
+
+	"github.com/luxfi/ringtail/utils"
+
+	"github.com/luxfi/lattice/v7/ring"
+	"github.com/luxfi/lattice/v7/utils/structs"
+)
+
+// ShareProof commits to a Shamir sharing polynomial f(x) = a_0 + a_1 x + ... +
+// a_{t-1} x^{t-1} over vectors of R_q, so each party can check its share lies
+// on f without learning the other coefficients. Commitments[j] = A·a_j + e_j
+// with short noise e_j, in NTT and Montgomery form. The noise keeps the
+// coefficients, including the secret a_0, hidden; without it A·a_0 alone would
+// determine the secret.
+type ShareProof struct {
+	Commitments []structs.Vector[ring.Poly]
+}
+
+// ShamirSecretSharingWithProof is ShamirSecretSharingGeneralFrom that also
+// commits to the sharing polynomial with ProveShare, so the dealer can be
+// checked by every party with VerifyShareProof.
+func ShamirSecretSharingWithProof(r *ring.Ring, A structs.Matrix[ring.Poly], s []ring.Poly, t, k int, rng io.Reader, noise ring.Sampler) (map[int]structs.Vector[ring.Poly], *ShareProof) {
+	shares, coefficients := shamirSharing(r, s, t, k, rng)
+	return shares, ProveShare(r, A, coefficients, noise)
+}
+
+// ProveShare commits to the coefficient-domain polynomial coefficients under
+// the NTT and Montgomery form matrix A, adding noise drawn from noise.
+func ProveShare(r *ring.Ring, A structs.Matrix[ring.Poly], coefficients []structs.Vector[ring.Poly], noise ring.Sampler) *ShareProof {
+	proof := &ShareProof{Commitments: make([]structs.Vector[ring.Poly], len(coefficients))}
+	for j, a := range coefficients {
+		aNTT := copyVector(a)
+		utils.ConvertVectorToNTT(r, aNTT)
+		commitment := utils.SamplePolyVector(r, len(A), noise, true, true)
+		Aa := utils.InitializeVector(r, len(A))
+		utils.MatrixVectorMul(r, A, aNTT, Aa)
+		utils.VectorAdd(r, commitment, Aa, commitment)
+		proof.Commitments[j] = commitment
+	}
+	return proof
+}
+
+// VerifyShareProof reports whether the coefficient-domain share of party i
+// (evaluated at x = i+1) lies on the committed polynomial. An honest share
+// leaves A·share - Σ_j x^j·Commitments[j] = -Σ_j x^j·e_j, so every coefficient
+// is at most noiseBound·Σ_j x^j, where noiseBound bounds the coefficients of
+// the commitment noise. Any other share leaves a uniform residual. The check is
+// only sound while that bound is far below Q/2.
+func VerifyShareProof(r *ring.Ring, A structs.Matrix[ring.Poly], proof *ShareProof, i int, share structs.Vector[ring.Poly], noiseBound uint64) bool {
+	if proof == nil || len(proof.Commitments) == 0 || len(A) == 0 || len(share) != len(A[0]) {
+		return false
+	}
+	for _, commitment := range proof.Commitments {
+		if len(commitment) != len(A) {
+			return false
+		}
+	}
+
+	q := r.Modulus()
+	x := big.NewInt(int64(i + 1))
+	xPow := big.NewInt(1)
+	bound := new(big.Int)
+
+	residual := utils.InitializeVector(r, len(A))
+	shareNTT := copyVector(share)
+	utils.ConvertVectorToNTT(r, shareNTT)
+	utils.MatrixVectorMul(r, A, shareNTT, residual)
+
+	term := utils.InitializeVector(r, len(A))
+	for _, commitment := range proof.Commitments {
+		bound.Add(bound, xPow)
+		scalar := r.NewPoly()
+		r.SetCoefficientsBigint([]*big.Int{new(big.Int).Mod(xPow, q)}, scalar)
+		r.NTT(scalar, scalar)
+		r.MForm(scalar, scalar)
+		utils.VectorPolyMul(r, commitment, scalar, term)
+		utils.VectorSub(r, residual, term, residual)
+		xPow.Mul(xPow, x)
+	}
+	bound.Mul(bound, new(big.Int).SetUint64(noiseBound))
+
+	half := new(big.Int).Rsh(q, 1)
+	if bound.Cmp(half) >= 0 {
+		return false
+	}
+	utils.ConvertVectorFromNTT(r, residual)
+	coeffs := make([]*big.Int, r.N())
+	for _, p := range residual {
+		r.PolyToBigint(p, 1, coeffs)
+		for _, coeff := range coeffs {
+			if coeff.Cmp(half) > 0 {
+				coeff.Sub(q, coeff)
+			}
+			if coeff.Cmp(bound) > 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func copyVector(v structs.Vector[ring.Poly]) structs.Vector[ring.Poly] {
+	c := make(structs.Vector[ring.Poly], len(v))
+	for i := range v {
+		c[i] = *v[i].CopyNew()
+	}
+	return c
+}
@@ -0,0 +1,73 @@
+package primitives
+
+import (
+	"testing"
+
+	"github.com/luxfi/ringtail/utils"
+
+	"github.com/luxfi/lattice/v7/ring"
+	"github.com/luxfi/lattice/v7/utils/sampling"
+	"github.com/luxfi/lattice/v7/utils/structs"
+)
+
+func TestShareProof(t *testing.T) {
+	r, err := ring.NewRing(256, []uint64{8380417})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prng, _ := sampling.NewPRNG()
+	uniform := ring.NewUniformSampler(prng, r)
+	noise := ring.NewGaussianSampler(prng, r, ring.DiscreteGaussian{Sigma: 3.2, Bound: 12}, false)
+	const noiseBound = 12
+
+	A := utils.SamplePolyMatrix(r, 4, 3, uniform, true, true)
+	secret := createTestSecret(r, uniform, 3)
+	threshold, parties := 3, 5
+
+	rng, _ := sampling.NewKeyedPRNG([]byte("share-proof-test-key-32-bytes!!!"))
+	shares, proof := ShamirSecretSharingWithProof(r, A, secret, threshold, parties, rng, noise)
+	if len(proof.Commitments) != threshold {
+		t.Fatalf("proof has %d commitments, want %d", len(proof.Commitments), threshold)
+	}
+
+	// The proof does not change the shares
+	rng, _ = sampling.NewKeyedPRNG([]byte("share-proof-test-key-32-bytes!!!"))
+	plain := ShamirSecretSharingGeneralFrom(r, secret, threshold, parties, rng)
+	for i := range plain {
+		for j := range plain[i] {
+			if !r.Equal(plain[i][j], shares[i][j]) {
+				t.Fatalf("share %d differs from ShamirSecretSharingGeneralFrom", i)
+			}
+		}
+	}
+
+	for i := 0; i < parties; i++ {
+		if !VerifyShareProof(r, A, proof, i, shares[i], noiseBound) {
+			t.Errorf("honest share %d rejected", i)
+		}
+	}
+
+	tampered := make(structs.Vector[ring.Poly], len(shares[2]))
+	for j := range shares[2] {
+		tampered[j] = *shares[2][j].CopyNew()
+	}
+	tampered[0].Coeffs[0][0] = (tampered[0].Coeffs[0][0] + 1) % r.Modulus().Uint64()
+
+	tests := []struct {
+		name  string
+		party int
+		share structs.Vector[ring.Poly]
+	}{
+		{name: "tampered share", party: 2, share: tampered},
+		{name: "share of another party", party: 1, share: shares[2]},
+		{name: "wrong length", party: 2, share: shares[2][:2]},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if VerifyShareProof(r, A, proof, tt.party, tt.share, noiseBound) {
+				t.Error("inconsistent share accepted")
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package sign
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+
+	"github.com/luxfi/lattice/v7/ring"
+)
+
+var (
+	// ErrUnknownPreset is returned by NewParamsPreset for an unrecognised name.
+	ErrUnknownPreset = errors.New("unknown parameter preset")
+	// ErrInvalidRoundingModulus is returned by Rings when QXi or QNu is not a power of 2.
+	ErrInvalidRoundingModulus = errors.New("rounding modulus must be a power of 2")
+	// ErrRingDegreeMismatch is returned by Rings when the three rings disagree on N.
+	ErrRingDegreeMismatch = errors.New("rings have different degrees")
+)
+
+// Preset is a named, vetted parameter set for the scheme. Only LogN, Q, QXi
+// and QNu are read, to build the rings; the other fields record the package
+// constants the signing code is written against and are informational.
+type Preset struct {
+	Name          string
+	SecurityLevel int // Classical security in bits
+	LogN          int
+	Q             uint64
+	QXi           uint64
+	QNu           uint64
+	Xi            int
+	Nu            int
+	M             int
+	N             int
+	Dbar          int
+	Kappa         int
+	KeySize       int
+	SigmaE        float64
+	BoundE        float64
+	SigmaStar     float64
+	BoundStar     float64
+	SigmaU        float64
+	BoundU        float64
+	Bsquare       string
+}
+
+// Presets returns the supported parameter sets. The package constants are the
+// only set the signing code implements, so that is the only preset listed;
+// custom parameters are not supported.
+func Presets() []Preset {
+	return []Preset{ringtail128()}
+}
+
+// NewParamsPreset returns the preset with the given name
+func NewParamsPreset(name string) (*Preset, error) {
+	for _, p := range Presets() {
+		if p.Name == name {
+			return &p, nil
+		}
+	}
+	return nil, ErrUnknownPreset
+}
+
+// Rings builds the main ring and the two power-of-two rounding rings of the preset
+func (p *Preset) Rings() (r *ring.Ring, rXi *ring.Ring, rNu *ring.Ring, err error) {
+	r, err = ring.NewRing(1<<p.LogN, []uint64{p.Q})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if rXi, err = roundingRing(1<<p.LogN, p.QXi); err != nil {
+		return nil, nil, nil, fmt.Errorf("QXi: %w", err)
+	}
+	if rNu, err = roundingRing(1<<p.LogN, p.QNu); err != nil {
+		return nil, nil, nil, fmt.Errorf("QNu: %w", err)
+	}
+	if rXi.N() != r.N() || rNu.N() != r.N() {
+		return nil, nil, nil, fmt.Errorf("%w: %d, %d, %d", ErrRingDegreeMismatch, r.N(), rXi.N(), rNu.N())
+	}
+	return r, rXi, rNu, nil
+}
+
+// roundingRing builds a ring of degree n over the power-of-two modulus q. Such
+// a ring has no NTT, so the NTT error from ring.NewRing is expected and
+// ignored; only the coefficient arithmetic used for rounding is needed.
+func roundingRing(n int, q uint64) (*ring.Ring, error) {
+	if q < 2 || bits.OnesCount64(q) != 1 {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidRoundingModulus, q)
+	}
+	rq, err := ring.NewRing(n, []uint64{q})
+	if rq == nil {
+		return nil, err
+	}
+	return rq, nil
+}
+
+func ringtail128() Preset {
+	return Preset{
+		Name:          "Ringtail-128",
+		SecurityLevel: 128,
+		LogN:          LogN,
+		Q:             Q,
+		QXi:           QXi,
+		QNu:           QNu,
+		Xi:            Xi,
+		Nu:            Nu,
+		M:             M,
+		N:             N,
+		Dbar:          Dbar,
+		Kappa:         Kappa,
+		KeySize:       KeySize,
+		SigmaE:        SigmaE,
+		BoundE:        BoundE,
+		SigmaStar:     SigmaStar,
+		BoundStar:     BoundStar,
+		SigmaU:        SigmaU,
+		BoundU:        BoundU,
+		Bsquare:       Bsquare,
+	}
+}
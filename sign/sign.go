@@ -2,6 +2,8 @@ package sign
 
 import (
 	"bytes"
+	"errors"
+	"fmt"
 	"log"
 	"math/big"
 
@@ -11,8 +13,27 @@ import (
 	"github.com/luxfi/lattice/v7/ring"
 	"github.com/luxfi/lattice/v7/utils/sampling"
 	"github.com/luxfi/lattice/v7/utils/structs"
+	"github.com/zeebo/blake3"
 )
 
+var (
+	// ErrInvalidD is returned when a D matrix received in round 1 is malformed.
+	ErrInvalidD = errors.New("invalid D matrix")
+	// ErrMissingRound1 is returned when a signer in T sent no round 1 data.
+	ErrMissingRound1 = errors.New("missing round 1 data")
+	// ErrInvalidMAC is returned when a pairwise MAC is missing or does not verify.
+	ErrInvalidMAC = errors.New("invalid MAC")
+	// ErrNotFullRank is returned when the aggregated D fails the full rank check.
+	ErrNotFullRank = errors.New("aggregated D is not full rank")
+	// ErrTrivialChallenge is returned when a challenge is not a ternary
+	// polynomial of Hamming weight Kappa.
+	ErrTrivialChallenge = errors.New("challenge is not a weight-kappa ternary polynomial")
+)
+
+// challengeHash computes the verification challenge. Tests replace it to
+// check that Verify rejects trivial challenges.
+var challengeHash = primitives.LowNormHashWithPrefix
+
 // Party struct holds all state and methods for a party in the protocol
 type Party struct {
 	ID             int
@@ -46,8 +67,28 @@ func NewParty(id int, r *ring.Ring, r_xi *ring.Ring, r_nu *ring.Ring, sampler *r
 
 // Gen generates the secret shares, seeds, MAC keys, and the public parameter b
 func Gen(r *ring.Ring, r_xi *ring.Ring, uniformSampler *ring.UniformSampler, trustedDealerKey []byte, lagrangeCoefficients structs.Vector[ring.Poly]) (structs.Matrix[ring.Poly], map[int]structs.Vector[ring.Poly], map[int][][]byte, map[int]map[int][]byte, structs.Vector[ring.Poly]) {
+	return GenWithSharing(r, r_xi, uniformSampler, trustedDealerKey, func(s structs.Vector[ring.Poly]) map[int]structs.Vector[ring.Poly] {
+		return primitives.ShamirSecretSharing(r, s, Threshold, lagrangeCoefficients)
+	})
+}
+
+// GenWithSharing is Gen with the secret split by share instead of the t = k sharing.
+// share receives s in the coefficient domain; the returned shares are converted to NTT form.
+// Seeds and MAC keys are still generated for K parties.
+func GenWithSharing(r *ring.Ring, r_xi *ring.Ring, uniformSampler *ring.UniformSampler, trustedDealerKey []byte, share func(s structs.Vector[ring.Poly]) map[int]structs.Vector[ring.Poly]) (structs.Matrix[ring.Poly], map[int]structs.Vector[ring.Poly], map[int][][]byte, map[int]map[int][]byte, structs.Vector[ring.Poly]) {
 	A := utils.SamplePolyMatrix(r, M, N, uniformSampler, true, true)
+	skShares, seeds, MACKeys, b := GenForMatrix(r, A, trustedDealerKey, share)
+
+	// Round b
+	bTilde := utils.RoundVector(r, r_xi, b, Xi)
+
+	return A, skShares, seeds, MACKeys, bTilde
+}
 
+// GenForMatrix is GenWithSharing under a given public matrix A, for dealers
+// that must agree on A. It returns the exact public key b = A·s + e in the
+// coefficient domain instead of its rounding.
+func GenForMatrix(r *ring.Ring, A structs.Matrix[ring.Poly], trustedDealerKey []byte, share func(s structs.Vector[ring.Poly]) map[int]structs.Vector[ring.Poly]) (map[int]structs.Vector[ring.Poly], map[int][][]byte, map[int]map[int][]byte, structs.Vector[ring.Poly]) {
 	precomputeSize := (K * K * KeySize) + (r.N() * N * (K - 1) * len(r.Modulus().Bytes())) + (K * (K - 1) * KeySize)
 	utils.PrecomputeRandomness(precomputeSize, trustedDealerKey)
 
@@ -56,7 +97,7 @@ func Gen(r *ring.Ring, r_xi *ring.Ring, uniformSampler *ring.UniformSampler, tru
 	gaussianSampler := ring.NewGaussianSampler(prng, r, gaussianParams, false)
 
 	s := utils.SamplePolyVector(r, N, gaussianSampler, false, false)
-	skShares := primitives.ShamirSecretSharing(r, s, Threshold, lagrangeCoefficients)
+	skShares := share(s)
 
 	for _, skShare := range skShares {
 		utils.ConvertVectorToNTT(r, skShare)
@@ -67,32 +108,54 @@ func Gen(r *ring.Ring, r_xi *ring.Ring, uniformSampler *ring.UniformSampler, tru
 	b := utils.InitializeVector(r, M)
 	utils.MatrixVectorMul(r, A, s, b)
 	utils.VectorAdd(r, b, e, b)
-
-	// Round b
 	utils.ConvertVectorFromNTT(r, b)
-	bTilde := utils.RoundVector(r, r_xi, b, Xi)
 
+	seeds, MACKeys := deriveSeedsAndMACKeys(K, utils.GetRandomBytes)
+
+	return skShares, seeds, MACKeys, b
+}
+
+// DeriveSeeds returns the pairwise seeds that a dealer with dealerKey deals to
+// n parties. They are drawn from the BLAKE3 stream of the dealer key,
+// interleaved with the MAC keys, as GenForMatrix draws them. This matches the
+// seeds of threshold keygen, which takes its Shamir polynomials from a separate
+// stream. Gen's t-of-t sharing draws from the dealer stream first, so its
+// seeds come later in the stream.
+func DeriveSeeds(dealerKey []byte, n int) map[int][][]byte {
+	hasher := blake3.New()
+	_, _ = hasher.Write(dealerKey)
+	stream := hasher.Digest()
+	seeds, _ := deriveSeedsAndMACKeys(n, func(size int) []byte {
+		b := make([]byte, size)
+		_, _ = stream.Read(b)
+		return b
+	})
+	return seeds
+}
+
+// deriveSeedsAndMACKeys draws the pairwise seeds and MAC keys of n parties
+// from next, which returns the next bytes of the dealer's randomness.
+func deriveSeedsAndMACKeys(n int, next func(int) []byte) (map[int][][]byte, map[int]map[int][]byte) {
 	seeds := make(map[int][][]byte)
 	MACKeys := make(map[int]map[int][]byte)
 	MACKeys[0] = make(map[int][]byte)
 
-	for i := 0; i < K; i++ {
-		seeds[i] = make([][]byte, K)
-		for j := 0; j < K; j++ {
-			seeds[i][j] = utils.GetRandomBytes(KeySize)
+	for i := 0; i < n; i++ {
+		seeds[i] = make([][]byte, n)
+		for j := 0; j < n; j++ {
+			seeds[i][j] = next(KeySize)
 			if i != j {
 				if MACKeys[j] == nil {
 					MACKeys[j] = make(map[int][]byte)
 				}
 				if MACKeys[i][j] == nil && MACKeys[j][i] == nil {
-					MACKeys[i][j] = utils.GetRandomBytes(KeySize)
+					MACKeys[i][j] = next(KeySize)
 					MACKeys[j][i] = MACKeys[i][j]
 				}
 			}
 		}
 	}
-
-	return A, skShares, seeds, MACKeys, bTilde
+	return seeds, MACKeys
 }
 
 // SignRound1 performs the first round of signing
@@ -150,28 +213,121 @@ func (party *Party) SignRound1(A structs.Matrix[ring.Poly], sid int, PRFKey []by
 
 // SignRound2Preprocess verifies the MACs received in round 1 and performs the minimum eigenvalue check
 func (party *Party) SignRound2Preprocess(A structs.Matrix[ring.Poly], b structs.Vector[ring.Poly], D map[int]structs.Matrix[ring.Poly], MACs map[int]map[int][]byte, sid int, T []int) (bool, structs.Matrix[ring.Poly], []byte) {
+	DSum, hash, err := party.VerifyRound1(A, b, D, MACs, sid, T)
+	if err != nil {
+		return false, nil, nil
+	}
+	return true, DSum, hash
+}
+
+// VerifyRound1 performs the checks of SignRound2Preprocess and reports which one failed.
+// Every party in T other than this one must have sent a valid MAC addressed to it.
+func (party *Party) VerifyRound1(A structs.Matrix[ring.Poly], b structs.Vector[ring.Poly], D map[int]structs.Matrix[ring.Poly], MACs map[int]map[int][]byte, sid int, T []int) (structs.Matrix[ring.Poly], []byte, error) {
+	for _, j := range T {
+		if _, ok := D[j]; !ok {
+			return nil, nil, fmt.Errorf("%w: no D matrix from party %d", ErrMissingRound1, j)
+		}
+	}
+	for j, D_j := range D {
+		if err := ValidateD(party.Ring, D_j, M, Dbar+1); err != nil {
+			return nil, nil, fmt.Errorf("party %d: %w", j, err)
+		}
+	}
+
 	hash := primitives.Hash(A, b, D, sid, T)
 
 	for _, j := range T {
 		if j != party.ID {
-			MAC := MACs[j][party.ID]
+			MAC, ok := MACs[j][party.ID]
+			if !ok {
+				return nil, nil, fmt.Errorf("%w: missing MAC from party %d to party %d", ErrInvalidMAC, j, party.ID)
+			}
 			expectedMAC := primitives.GenerateMAC(D[j], party.MACKeys[j], party.ID, sid, T, j, true)
 			if !bytes.Equal(MAC, expectedMAC) {
-				return false, nil, nil
+				return nil, nil, fmt.Errorf("%w: bad MAC from party %d to party %d", ErrInvalidMAC, j, party.ID)
 			}
 		}
 	}
 
-	DSum := utils.InitializeMatrix(party.Ring, M, Dbar+1)
+	DSum := SumD(party.Ring, D)
+
+	if !FullRankCheck(DSum, party.Ring) {
+		return nil, nil, ErrNotFullRank
+	}
+
+	return DSum, hash, nil
+}
+
+// SumD adds up the round 1 D matrices of every signer.
+func SumD(r *ring.Ring, D map[int]structs.Matrix[ring.Poly]) structs.Matrix[ring.Poly] {
+	DSum := utils.InitializeMatrix(r, M, Dbar+1)
 	for _, D_j := range D {
-		utils.MatrixAdd(party.Ring, D_j, DSum, DSum)
+		utils.MatrixAdd(r, D_j, DSum, DSum)
 	}
+	return DSum
+}
 
-	if !FullRankCheck(DSum, party.Ring) {
-		return false, nil, nil
+// ValidateD checks that a D matrix received from a peer has the expected dimensions
+// and that every entry is a polynomial of r with coefficients reduced modulo q.
+func ValidateD(r *ring.Ring, D structs.Matrix[ring.Poly], expectedRows, expectedCols int) error {
+	if len(D) != expectedRows {
+		return fmt.Errorf("%w: %d rows, want %d", ErrInvalidD, len(D), expectedRows)
 	}
+	q := r.Modulus().Uint64()
+	for i, row := range D {
+		if len(row) != expectedCols {
+			return fmt.Errorf("%w: row %d has %d columns, want %d", ErrInvalidD, i, len(row), expectedCols)
+		}
+		for j, poly := range row {
+			if len(poly.Coeffs) != 1 || len(poly.Coeffs[0]) != r.N() {
+				return fmt.Errorf("%w: entry [%d][%d] is not a degree-%d polynomial", ErrInvalidD, i, j, r.N())
+			}
+			if !reducedPoly(r.N(), q, poly) {
+				return fmt.Errorf("%w: entry [%d][%d] has a coefficient outside [0, q)", ErrInvalidD, i, j)
+			}
+		}
+	}
+	return nil
+}
 
-	return true, DSum, hash
+// reducedPoly reports whether p is a single-level polynomial of degree n with
+// every coefficient in [0, q).
+func reducedPoly(n int, q uint64, p ring.Poly) bool {
+	if len(p.Coeffs) != 1 || len(p.Coeffs[0]) != n {
+		return false
+	}
+	for _, coeff := range p.Coeffs[0] {
+		if coeff >= q {
+			return false
+		}
+	}
+	return true
+}
+
+// CanonicalSignature reports whether z and c are N and one polynomials of r,
+// and roundedDelta M polynomials of r_nu, with every coefficient reduced. The
+// arithmetic of Verify is lazy about reduction, so a signature with q added to
+// a coefficient of z, say, would otherwise verify as a second encoding of the
+// same signature.
+func CanonicalSignature(r *ring.Ring, r_nu *ring.Ring, z structs.Vector[ring.Poly], c ring.Poly, roundedDelta structs.Vector[ring.Poly]) bool {
+	if len(z) != N || len(roundedDelta) != M {
+		return false
+	}
+	q, qNu := r.Modulus().Uint64(), r_nu.Modulus().Uint64()
+	if !reducedPoly(r.N(), q, c) {
+		return false
+	}
+	for _, p := range z {
+		if !reducedPoly(r.N(), q, p) {
+			return false
+		}
+	}
+	for _, p := range roundedDelta {
+		if !reducedPoly(r_nu.N(), qNu, p) {
+			return false
+		}
+	}
+	return true
 }
 
 // SignRound2 performs the second round of signing
@@ -207,15 +363,16 @@ func (party *Party) SignRound2(A structs.Matrix[ring.Poly], bTilde structs.Vecto
 	party.C = c
 
 	seed_i := party.Seed[party.ID]
+	mask_j := utils.InitializeVector(r, N)
 	mask := utils.InitializeVector(r, N)
 	for _, j := range T {
-		mask_j := primitives.PRF(r, seed_i[j], PRFKey, mu, hash, N)
+		primitives.PRFInto(r, seed_i[j], PRFKey, mu, hash, mask_j)
 		utils.VectorAdd(r, mask, mask_j, mask)
 	}
 
 	maskPrime := utils.InitializeVector(r, N)
 	for _, j := range T {
-		mask_j := primitives.PRF(r, seeds[j][partyID], PRFKey, mu, hash, N)
+		primitives.PRFInto(r, seeds[j][partyID], PRFKey, mu, hash, mask_j)
 		utils.VectorAdd(r, maskPrime, mask_j, maskPrime)
 	}
 
@@ -271,6 +428,38 @@ func (party *Party) SignFinalize(z map[int]structs.Vector[ring.Poly], A structs.
 // Verify verifies the correctness of the signature.
 // Note: This function does not modify its inputs - it creates copies where needed.
 func Verify(r *ring.Ring, r_xi *ring.Ring, r_nu *ring.Ring, z structs.Vector[ring.Poly], A structs.Matrix[ring.Poly], mu string, bTilde structs.Vector[ring.Poly], c ring.Poly, roundedDelta structs.Vector[ring.Poly]) bool {
+	return VerifyPrepared(r, r_nu, PrepareKey(r, r_xi, A, bTilde), z, mu, c, roundedDelta)
+}
+
+// PreparedKey holds the parts of verification that depend only on the public key
+type PreparedKey struct {
+	A          structs.Matrix[ring.Poly]
+	BTilde     structs.Vector[ring.Poly]
+	B          structs.Vector[ring.Poly] // b restored from BTilde, in NTT form
+	HashPrefix []byte                    // LowNormHash input prefix for (A, BTilde)
+}
+
+// PrepareKey precomputes the key-dependent work of Verify so it can be shared across signatures
+func PrepareKey(r *ring.Ring, r_xi *ring.Ring, A structs.Matrix[ring.Poly], bTilde structs.Vector[ring.Poly]) *PreparedKey {
+	b := utils.RestoreVector(r, r_xi, bTilde, Xi)
+	utils.ConvertVectorToNTT(r, b)
+
+	return &PreparedKey{
+		A:          A,
+		BTilde:     bTilde,
+		B:          b,
+		HashPrefix: primitives.LowNormHashPrefix(A, bTilde),
+	}
+}
+
+// VerifyPrepared is Verify against a key from PrepareKey. It modifies neither its inputs nor pk.
+// Signatures that are not CanonicalSignature are rejected, and so is every
+// signature if the recomputed challenge fails CheckChallenge.
+func VerifyPrepared(r *ring.Ring, r_nu *ring.Ring, pk *PreparedKey, z structs.Vector[ring.Poly], mu string, c ring.Poly, roundedDelta structs.Vector[ring.Poly]) bool {
+	if !CanonicalSignature(r, r_nu, z, c, roundedDelta) {
+		return false
+	}
+
 	// Make a copy of z to avoid modifying the input signature
 	zCopy := make(structs.Vector[ring.Poly], len(z))
 	for i := range z {
@@ -278,13 +467,10 @@ func Verify(r *ring.Ring, r_xi *ring.Ring, r_nu *ring.Ring, z structs.Vector[rin
 	}
 
 	Az_bc := utils.InitializeVector(r, M)
-	utils.MatrixVectorMul(r, A, zCopy, Az_bc)
+	utils.MatrixVectorMul(r, pk.A, zCopy, Az_bc)
 	bc := utils.InitializeVector(r, M)
 
-	b := utils.RestoreVector(r, r_xi, bTilde, Xi)
-	utils.ConvertVectorToNTT(r, b)
-
-	utils.VectorPolyMul(r, b, c, bc)
+	utils.VectorPolyMul(r, pk.B, c, bc)
 	utils.VectorSub(r, Az_bc, bc, Az_bc)
 
 	utils.ConvertVectorFromNTT(r, Az_bc)
@@ -293,8 +479,8 @@ func Verify(r *ring.Ring, r_xi *ring.Ring, r_nu *ring.Ring, z structs.Vector[rin
 	Az_bc_Delta := utils.InitializeVector(r_nu, M)
 	utils.VectorAdd(r_nu, roundedAz_bc, roundedDelta, Az_bc_Delta)
 
-	computedC := primitives.LowNormHash(r, A, bTilde, Az_bc_Delta, mu, Kappa)
-	if !r.Equal(c, computedC) {
+	computedC := challengeHash(r, pk.HashPrefix, Az_bc_Delta, mu, Kappa)
+	if CheckChallenge(r, computedC, Kappa) != nil || !r.Equal(c, computedC) {
 		return false
 	}
 
@@ -304,8 +490,44 @@ func Verify(r *ring.Ring, r_xi *ring.Ring, r_nu *ring.Ring, z structs.Vector[rin
 	return CheckL2Norm(r, Delta, zCopy)
 }
 
+// CheckChallenge returns ErrTrivialChallenge unless c, in NTT and Montgomery
+// form, has exactly kappa nonzero coefficients, each 1 or -1, as LowNormHash
+// produces. Under a zero challenge the all-zero signature would verify for
+// every message.
+func CheckChallenge(r *ring.Ring, c ring.Poly, kappa int) error {
+	coeffs := *c.CopyNew()
+	r.IMForm(coeffs, coeffs)
+	r.INTT(coeffs, coeffs)
+	q := r.Modulus().Uint64()
+	weight := 0
+	for _, coeff := range coeffs.Coeffs[0] {
+		switch coeff {
+		case 0:
+		case 1, q - 1:
+			weight++
+		default:
+			return fmt.Errorf("%w: coefficient %d is not ternary", ErrTrivialChallenge, coeff)
+		}
+	}
+	if weight != kappa {
+		return fmt.Errorf("%w: weight %d, want %d", ErrTrivialChallenge, weight, kappa)
+	}
+	return nil
+}
+
 // CheckL2Norm checks if the L2 norm of the vector of Delta is less than or equal to Bsquare
 func CheckL2Norm(r *ring.Ring, Delta structs.Vector[ring.Poly], z structs.Vector[ring.Poly]) bool {
+	sumSquares := L2NormSquared(r, Delta, z)
+
+	log.Println("Sum of Squares:", sumSquares)
+	log.Println("Bsquare:", Bsquare)
+
+	Bsquare, _ := new(big.Int).SetString(Bsquare, 10)
+	return sumSquares.Cmp(Bsquare) <= 0
+}
+
+// L2NormSquared returns the sum of the squared centered coefficients of Delta and z
+func L2NormSquared(r *ring.Ring, Delta structs.Vector[ring.Poly], z structs.Vector[ring.Poly]) *big.Int {
 	sumSquares := big.NewInt(0)
 	qBig := new(big.Int).SetUint64(Q)
 	halfQ := new(big.Int).Div(qBig, big.NewInt(2))
@@ -342,11 +564,30 @@ func CheckL2Norm(r *ring.Ring, Delta structs.Vector[ring.Poly], z structs.Vector
 		}
 	}
 
-	log.Println("Sum of Squares:", sumSquares)
-	log.Println("Bsquare:", Bsquare)
+	return sumSquares
+}
 
-	Bsquare, _ := new(big.Int).SetString(Bsquare, 10)
-	return sumSquares.Cmp(Bsquare) <= 0
+// RoundingError returns b - 2^Xi·round(b / 2^Xi), the error Gen introduces
+// when it publishes the rounded key bTilde of the coefficient-domain public
+// key b. Every coefficient lies in [-2^(Xi-1), 2^(Xi-1)], stored modulo Q.
+func RoundingError(r *ring.Ring, r_xi *ring.Ring, b structs.Vector[ring.Poly]) structs.Vector[ring.Poly] {
+	restored := utils.RestoreVector(r, r_xi, utils.RoundVector(r, r_xi, b, Xi), Xi)
+	roundingError := utils.InitializeVector(r, len(b))
+	utils.VectorSub(r, b, restored, roundingError)
+	return roundingError
+}
+
+// SignatureNormSquared returns the squared L2 norm that Verify bounds by Bsquare,
+// for z in NTT form and a rounded Delta. It does not modify its inputs.
+func SignatureNormSquared(r *ring.Ring, r_nu *ring.Ring, z structs.Vector[ring.Poly], roundedDelta structs.Vector[ring.Poly]) *big.Int {
+	zCopy := make(structs.Vector[ring.Poly], len(z))
+	for i := range z {
+		zCopy[i] = *z[i].CopyNew()
+	}
+	utils.ConvertVectorFromNTT(r, zCopy)
+	Delta := utils.RestoreVector(r, r_nu, roundedDelta, Nu)
+
+	return L2NormSquared(r, Delta, zCopy)
 }
 
 // FullRankCheck checks if the given matrix is full-rank, ignoring the first column
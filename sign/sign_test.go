@@ -1,8 +1,13 @@
 package sign
 
 import (
+	"errors"
+	"math/big"
 	"testing"
 
+	"github.com/luxfi/ringtail/primitives"
+	"github.com/luxfi/ringtail/utils"
+
 	"github.com/luxfi/lattice/v7/ring"
 	"github.com/luxfi/lattice/v7/utils/sampling"
 	"github.com/luxfi/lattice/v7/utils/structs"
@@ -108,3 +113,261 @@ func TestCheckL2Norm(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckL2NormBeyondUint64(t *testing.T) {
+	r, err := ring.NewRing(1<<LogN, []uint64{Q})
+	if err != nil {
+		t.Fatal(err)
+	}
+	bound, _ := new(big.Int).SetString(Bsquare, 10)
+
+	tests := []struct {
+		name   string
+		coeff  uint64 // Every coefficient of z, centered at -coeff when negative
+		neg    bool
+		expect bool
+	}{
+		// 7·256 squares of 2^80 sum to about 2^90.8: past 2^64, below Bsquare ≈ 2^97.2.
+		{name: "sum above 2^64 within bound", coeff: 1 << 40, expect: true},
+		{name: "negative coefficients within bound", coeff: 1 << 40, neg: true, expect: true},
+		// Each square alone is about 2^94; together they sum to about 2^104.8.
+		{name: "coefficients near Q/2", coeff: (Q - 1) / 2, expect: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			z := make(structs.Vector[ring.Poly], N)
+			for i := range z {
+				z[i] = r.NewPoly()
+				for j := 0; j < r.N(); j++ {
+					z[i].Coeffs[0][j] = tt.coeff
+					if tt.neg {
+						z[i].Coeffs[0][j] = Q - tt.coeff
+					}
+				}
+			}
+			delta := structs.Vector[ring.Poly]{}
+
+			want := new(big.Int).SetUint64(tt.coeff)
+			want.Mul(want, want)
+			want.Mul(want, big.NewInt(int64(N*r.N())))
+			if got := L2NormSquared(r, delta, z); got.Cmp(want) != 0 {
+				t.Fatalf("L2NormSquared = %v, want %v", got, want)
+			}
+			if got := CheckL2Norm(r, delta, z); got != tt.expect {
+				t.Errorf("CheckL2Norm = %v, want %v (norm %v, bound %v)", got, tt.expect, want, bound)
+			}
+		})
+	}
+}
+
+func TestValidateD(t *testing.T) {
+	r, err := ring.NewRing(256, []uint64{8380417})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newD := func(rows, cols int) structs.Matrix[ring.Poly] {
+		D := make(structs.Matrix[ring.Poly], rows)
+		for i := range D {
+			D[i] = make(structs.Vector[ring.Poly], cols)
+			for j := range D[i] {
+				D[i][j] = r.NewPoly()
+			}
+		}
+		return D
+	}
+
+	outOfRange := newD(M, Dbar+1)
+	outOfRange[2][3].Coeffs[0][7] = 8380417
+
+	tests := []struct {
+		name    string
+		D       structs.Matrix[ring.Poly]
+		wantErr bool
+	}{
+		{name: "well formed", D: newD(M, Dbar+1)},
+		{name: "missing row", D: newD(M-1, Dbar+1), wantErr: true},
+		{name: "extra column", D: newD(M, Dbar+2), wantErr: true},
+		{name: "nil matrix", D: nil, wantErr: true},
+		{name: "coefficient not reduced", D: outOfRange, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateD(r, tt.D, M, Dbar+1)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("ValidateD() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidD) {
+				t.Errorf("ValidateD() error %v does not wrap ErrInvalidD", err)
+			}
+		})
+	}
+
+	party := NewParty(0, r, r, r, nil)
+	D := map[int]structs.Matrix[ring.Poly]{0: newD(M, Dbar), 1: newD(M, Dbar+1)}
+	if valid, _, _ := party.SignRound2Preprocess(nil, nil, D, nil, 1, []int{0, 1}); valid {
+		t.Error("SignRound2Preprocess accepted a wrong-size D")
+	}
+}
+
+func TestNewParamsPreset(t *testing.T) {
+	tests := []struct {
+		name    string
+		preset  string
+		wantErr error
+	}{
+		{name: "Ringtail-128", preset: "Ringtail-128"},
+		{name: "unknown", preset: "Ringtail-1024", wantErr: ErrUnknownPreset},
+		{name: "empty", preset: "", wantErr: ErrUnknownPreset},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := NewParamsPreset(tt.preset)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewParamsPreset(%q) error = %v, want %v", tt.preset, err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if p.Name != tt.preset {
+				t.Errorf("NewParamsPreset(%q) returned preset %q", tt.preset, p.Name)
+			}
+
+			r, rXi, rNu, err := p.Rings()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if r.N() != 1<<p.LogN || r.Modulus().Uint64() != p.Q {
+				t.Errorf("main ring is N=%d Q=%d, want N=%d Q=%d", r.N(), r.Modulus().Uint64(), 1<<p.LogN, p.Q)
+			}
+			if rXi.Modulus().Uint64() != p.QXi || rNu.Modulus().Uint64() != p.QNu {
+				t.Errorf("rounding rings have moduli %d and %d, want %d and %d",
+					rXi.Modulus().Uint64(), rNu.Modulus().Uint64(), p.QXi, p.QNu)
+			}
+		})
+	}
+}
+
+func TestRoundingError(t *testing.T) {
+	preset, err := NewParamsPreset("Ringtail-128")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, rXi, _, err := preset.Rings()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prng, _ := sampling.NewPRNG()
+	b := utils.SamplePolyVector(r, M, ring.NewUniformSampler(prng, r), false, false)
+	roundingError := RoundingError(r, rXi, b)
+
+	q := r.Modulus()
+	half := new(big.Int).Rsh(q, 1)
+	bound := big.NewInt(1 << (Xi - 1))
+	coeffs := make([]*big.Int, r.N())
+	for i := range roundingError {
+		r.PolyToBigint(roundingError[i], 1, coeffs)
+		for j, coeff := range coeffs {
+			if coeff.Cmp(half) > 0 {
+				coeff.Sub(q, coeff)
+			}
+			if coeff.Cmp(bound) > 0 {
+				t.Fatalf("coefficient %d of element %d has rounding error %v > 2^(Xi-1)", j, i, coeff)
+			}
+		}
+	}
+
+	// Restoring bTilde and adding the error gives back b
+	restored := utils.RestoreVector(r, rXi, utils.RoundVector(r, rXi, b, Xi), Xi)
+	utils.VectorAdd(r, restored, roundingError, restored)
+	for i := range b {
+		if !r.Equal(restored[i], b[i]) {
+			t.Errorf("element %d: restored key plus rounding error differs from b", i)
+		}
+	}
+}
+
+func TestCheckChallenge(t *testing.T) {
+	r, err := ring.NewRing(256, []uint64{8380417})
+	if err != nil {
+		t.Fatal(err)
+	}
+	toNTT := func(p ring.Poly) ring.Poly {
+		r.NTT(p, p)
+		r.MForm(p, p)
+		return p
+	}
+	withCoeffs := func(coeffs ...uint64) ring.Poly {
+		p := r.NewPoly()
+		copy(p.Coeffs[0], coeffs)
+		return toNTT(p)
+	}
+	ones := func(n int) []uint64 {
+		coeffs := make([]uint64, n)
+		for i := range coeffs {
+			coeffs[i] = 1
+		}
+		return coeffs
+	}
+
+	h := structs.Vector[ring.Poly]{r.NewPoly()}
+	honest := primitives.LowNormHashWithPrefix(r, []byte("prefix"), h, "message", Kappa)
+	negated := append(ones(Kappa-1), 8380416)
+
+	tests := []struct {
+		name    string
+		c       ring.Poly
+		wantErr bool
+	}{
+		{name: "hash output", c: honest},
+		{name: "weight kappa with a -1", c: withCoeffs(negated...)},
+		{name: "zero", c: r.NewPoly(), wantErr: true},
+		{name: "weight kappa-1", c: withCoeffs(ones(Kappa - 1)...), wantErr: true},
+		{name: "weight kappa+1", c: withCoeffs(ones(Kappa + 1)...), wantErr: true},
+		{name: "non-ternary coefficient", c: withCoeffs(append(ones(Kappa-1), 2)...), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckChallenge(r, tt.c, Kappa)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("CheckChallenge() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrTrivialChallenge) {
+				t.Errorf("CheckChallenge() error %v does not wrap ErrTrivialChallenge", err)
+			}
+		})
+	}
+}
+
+func TestVerifyRejectsZeroChallenge(t *testing.T) {
+	preset, err := NewParamsPreset("Ringtail-128")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, rXi, rNu, err := preset.Rings()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// If the challenge hash returned zero, the all-zero signature would pass
+	// both the challenge comparison and the norm bound for any key.
+	defer func(hash func(*ring.Ring, []byte, structs.Vector[ring.Poly], string, int) ring.Poly) {
+		challengeHash = hash
+	}(challengeHash)
+	challengeHash = func(r *ring.Ring, _ []byte, _ structs.Vector[ring.Poly], _ string, _ int) ring.Poly {
+		return r.NewPoly()
+	}
+
+	prng, _ := sampling.NewPRNG()
+	A := utils.SamplePolyMatrix(r, M, N, ring.NewUniformSampler(prng, r), true, true)
+	bTilde := utils.InitializeVector(rXi, M)
+	z := utils.InitializeVector(r, N)
+	Delta := utils.InitializeVector(rNu, M)
+	if Verify(r, rXi, rNu, z, A, "forged", bTilde, r.NewPoly(), Delta) {
+		t.Error("Verify accepted the all-zero signature under a zero challenge")
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright (C) 2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package threshold
+
+import (
+	"fmt"
+	"io"
+	"runtime"
+	"sync"
+)
+
+// KeygenConfig describes one group for GenerateKeysBatch.
+type KeygenConfig struct {
+	Threshold  int
+	Parties    int       // Number of parties, each of weight one; ignored if Weights is set
+	Weights    []int     // Share slots held by each party, as in GenerateWeightedKeys
+	RandSource io.Reader // Dealer randomness; crypto/rand if nil
+}
+
+// GroupResult is the output of keygen for one group.
+type GroupResult struct {
+	Shares   []*KeyShare
+	GroupKey *GroupKey
+}
+
+// GenerateKeysBatch generates the keys of independent groups concurrently,
+// for example one group per subnet. The groups share one set of ring
+// parameters, and each group's keys are those that GenerateKeys or
+// GenerateWeightedKeys would produce from the same RandSource. Dealing under
+// the sign package's globals is serialized; the rest of keygen runs in
+// parallel. If any group fails, the error of the first failing one is
+// returned.
+func GenerateKeysBatch(configs []KeygenConfig) ([]*GroupResult, error) {
+	params, err := NewParams()
+	if err != nil {
+		return nil, err
+	}
+
+	weights := make([][]int, len(configs))
+	for i, config := range configs {
+		if config.Weights != nil {
+			weights[i] = config.Weights
+			continue
+		}
+		if weights[i], err = unitWeights(config.Threshold, config.Parties); err != nil {
+			return nil, fmt.Errorf("group %d: %w", i, err)
+		}
+	}
+
+	results := make([]*GroupResult, len(configs))
+	errs := make([]error, len(configs))
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(runtime.GOMAXPROCS(0), len(configs)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				shares, groupKey, err := generateKeys(params, weights[i], configs[i].Threshold, nil, configs[i].RandSource)
+				results[i], errs[i] = &GroupResult{Shares: shares, GroupKey: groupKey}, err
+			}
+		}()
+	}
+	for i := range configs {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			return nil, fmt.Errorf("group %d: %w", i, err)
+		}
+	}
+	return results, nil
+}
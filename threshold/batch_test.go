@@ -0,0 +1,84 @@
+// Copyright (C) 2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package threshold
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/luxfi/ringtail/utils"
+)
+
+func TestGenerateKeysBatch(t *testing.T) {
+	seed := func(i int) *bytes.Reader {
+		return bytes.NewReader(bytes.Repeat([]byte{byte(i + 1)}, 32))
+	}
+	configs := []KeygenConfig{
+		{Threshold: 2, Parties: 3},
+		{Threshold: 3, Parties: 5},
+		{Threshold: 3, Weights: []int{3, 1, 1}},
+		{Threshold: 1, Parties: 2},
+	}
+	for i := range configs {
+		configs[i].RandSource = seed(i)
+	}
+
+	results, err := GenerateKeysBatch(configs)
+	if err != nil {
+		t.Fatalf("GenerateKeysBatch failed: %v", err)
+	}
+	if len(results) != len(configs) {
+		t.Fatalf("got %d groups, want %d", len(results), len(configs))
+	}
+
+	for i, config := range configs {
+		t.Run(fmt.Sprintf("group %d", i), func(t *testing.T) {
+			var serial *GroupKey
+			if config.Weights != nil {
+				_, serial, err = GenerateWeightedKeys(config.Weights, config.Threshold, seed(i))
+			} else {
+				_, serial, err = GenerateKeys(config.Threshold, config.Parties, seed(i))
+			}
+			if err != nil {
+				t.Fatalf("serial keygen failed: %v", err)
+			}
+
+			result := results[i]
+			r := result.GroupKey.Params.R
+			if !utils.VectorEqual(result.GroupKey.Params.RXi, result.GroupKey.BTilde, serial.BTilde) {
+				t.Error("batch public key differs from serial keygen")
+			}
+			for k := range serial.A {
+				if !utils.VectorEqual(r, result.GroupKey.A[k], serial.A[k]) {
+					t.Errorf("row %d of A differs from serial keygen", k)
+				}
+			}
+
+			// The first parties whose weight reaches this group's threshold.
+			var signerIDs []int
+			for id, weight := 0, 0; weight < result.GroupKey.Threshold; id++ {
+				signerIDs = append(signerIDs, id)
+				weight += result.GroupKey.Weights[id]
+			}
+			message := fmt.Sprintf("group %d", i)
+			sig, err := signSession(newSigners(result.Shares), signerIDs, 1, message)
+			if err != nil {
+				t.Fatalf("signing failed: %v", err)
+			}
+			if !Verify(result.GroupKey, message, sig) {
+				t.Error("signature did not verify under the group key")
+			}
+			if other := results[(i+1)%len(results)].GroupKey; Verify(other, message, sig) {
+				t.Error("signature verified under another group's key")
+			}
+		})
+	}
+
+	bad := []KeygenConfig{{Threshold: 2, Parties: 3}, {Threshold: 3, Parties: 3}}
+	if _, err := GenerateKeysBatch(bad); !errors.Is(err, ErrInvalidThreshold) {
+		t.Errorf("expected ErrInvalidThreshold, got %v", err)
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright (C) 2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package threshold
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/luxfi/ringtail/utils"
+
+	"github.com/luxfi/lattice/v7/ring"
+	"github.com/luxfi/lattice/v7/utils/structs"
+)
+
+// bundleTag prefixes messages signed for a SignedBundle. Messages beginning
+// with it are reserved like those beginning with expiryTag.
+const bundleTag = "RingtailBundleV1\x00"
+
+// signedBundleVersion is the first byte of every encoded SignedBundle.
+const signedBundleVersion = 1
+
+// bundleMessage binds the domain and epoch into the signed transcript ahead
+// of message.
+func bundleMessage(domain string, epoch uint64, message []byte) string {
+	header := make([]byte, 12)
+	utils.TranscriptByteOrder().PutUint64(header, epoch)
+	utils.TranscriptByteOrder().PutUint32(header[8:], uint32(len(domain)))
+	return bundleTag + string(header) + domain + string(message)
+}
+
+// SignedBundle is a signature with everything needed to verify it: the
+// signed message with its domain and epoch, and the group key. A is most of
+// its size; keygen draws A from the secret dealer stream, so there is no
+// public seed to send in its place.
+type SignedBundle struct {
+	Epoch     uint64
+	Domain    string
+	Message   []byte
+	KeyDigest [32]byte  // VerificationKeyDigest of GroupKey
+	GroupKey  *GroupKey // A, BTilde and Params only
+	Signature *Signature
+}
+
+// Round2Bundle is Round2 for a signature that SignBundle will package. Every
+// signer must use the same domain, epoch and message.
+func (s *Signer) Round2Bundle(sessionID int, domain string, epoch uint64, message []byte, prfKey []byte, signers []int, round1Data map[int]*Round1Data) (*Round2Data, error) {
+	return s.Round2(sessionID, bundleMessage(domain, epoch, message), prfKey, signers, round1Data)
+}
+
+// SignBundle finalizes the z shares of a Round2Bundle session and packages
+// the signature with the signer's group key.
+func (s *Signer) SignBundle(domain string, epoch uint64, message []byte, round2Data map[int]*Round2Data) (*SignedBundle, error) {
+	sig, err := s.Finalize(round2Data)
+	if err != nil {
+		return nil, err
+	}
+	gk := s.share.GroupKey
+	return &SignedBundle{
+		Epoch:     epoch,
+		Domain:    domain,
+		Message:   append([]byte(nil), message...),
+		KeyDigest: VerificationKeyDigest(gk),
+		GroupKey:  &GroupKey{A: gk.A, BTilde: gk.BTilde, Params: gk.Params},
+		Signature: sig,
+	}, nil
+}
+
+// Verify reports whether the bundle's signature is valid for its domain,
+// epoch and message under the embedded group key, and whether KeyDigest is
+// that key's digest. It proves only that some key with KeyDigest signed; a
+// verifier must still compare KeyDigest with the digest it trusts.
+func (b *SignedBundle) Verify() bool {
+	return VerifyWithDigest(b.KeyDigest, b.GroupKey, bundleMessage(b.Domain, b.Epoch, b.Message), b.Signature)
+}
+
+// MarshalBinary encodes the bundle as
+//
+//	version (1 byte) || epoch (u64) || domain length (u32) || domain ||
+//	message length (u32) || message || key digest (32 bytes) ||
+//	A || BTilde || c || z || Delta
+//
+// with integers in utils.WireByteOrder and the polynomials in the lattice
+// encoding, c as a vector of one.
+func (b *SignedBundle) MarshalBinary() ([]byte, error) {
+	if b.GroupKey == nil || b.Signature == nil {
+		return nil, fmt.Errorf("%w: incomplete bundle", ErrInvalidEncoding)
+	}
+	buf := new(bytes.Buffer)
+	buf.WriteByte(signedBundleVersion)
+	writeUint64(buf, b.Epoch)
+	writeUint32(buf, uint32(len(b.Domain)))
+	buf.WriteString(b.Domain)
+	writeUint32(buf, uint32(len(b.Message)))
+	buf.Write(b.Message)
+	buf.Write(b.KeyDigest[:])
+	for _, w := range []io.WriterTo{
+		b.GroupKey.A,
+		b.GroupKey.BTilde,
+		structs.Vector[ring.Poly]{b.Signature.C},
+		b.Signature.Z,
+		b.Signature.Delta,
+	} {
+		if _, err := w.WriteTo(buf); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary decodes a bundle produced by MarshalBinary. The group key
+// gets the default parameters; Verify fails if they are not the ones the
+// bundle was signed under, since the key digest binds them.
+func (b *SignedBundle) UnmarshalBinary(data []byte) error {
+	reader := bufio.NewReader(bytes.NewReader(data))
+	version, err := reader.ReadByte()
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidEncoding, err)
+	}
+	if version != signedBundleVersion {
+		return fmt.Errorf("%w: unsupported bundle version %d", ErrInvalidEncoding, version)
+	}
+	epoch, err := readUint64(reader)
+	if err != nil {
+		return err
+	}
+	domain, err := readBundleBytes(reader, len(data))
+	if err != nil {
+		return err
+	}
+	message, err := readBundleBytes(reader, len(data))
+	if err != nil {
+		return err
+	}
+	var digest [32]byte
+	if _, err := io.ReadFull(reader, digest[:]); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidEncoding, err)
+	}
+
+	var A structs.Matrix[ring.Poly]
+	var bTilde, c, z, delta structs.Vector[ring.Poly]
+	for _, r := range []io.ReaderFrom{&A, &bTilde, &c, &z, &delta} {
+		if _, err := r.ReadFrom(reader); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidEncoding, err)
+		}
+	}
+	if len(c) != 1 {
+		return fmt.Errorf("%w: %d challenge polynomials", ErrInvalidEncoding, len(c))
+	}
+	if _, err := reader.ReadByte(); err != io.EOF {
+		return fmt.Errorf("%w: trailing bytes", ErrInvalidEncoding)
+	}
+	params, err := NewParams()
+	if err != nil {
+		return err
+	}
+
+	*b = SignedBundle{
+		Epoch:     epoch,
+		Domain:    string(domain),
+		Message:   message,
+		KeyDigest: digest,
+		GroupKey:  &GroupKey{A: A, BTilde: bTilde, Params: params},
+		Signature: &Signature{C: c[0], Z: z, Delta: delta},
+	}
+	return nil
+}
+
+func readBundleBytes(reader io.Reader, limit int) ([]byte, error) {
+	length, err := readUint32(reader)
+	if err != nil {
+		return nil, err
+	}
+	if int(length) > limit {
+		return nil, fmt.Errorf("%w: field length %d exceeds input", ErrInvalidEncoding, length)
+	}
+	b := make([]byte, length)
+	if _, err := io.ReadFull(reader, b); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidEncoding, err)
+	}
+	return b, nil
+}
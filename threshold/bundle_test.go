@@ -0,0 +1,92 @@
+// Copyright (C) 2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package threshold
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSignBundle(t *testing.T) {
+	shares, groupKey, err := GenerateKeys(2, 3, nil)
+	if err != nil {
+		t.Fatalf("GenerateKeys failed: %v", err)
+	}
+	signers := newSigners(shares)
+	prfKey := []byte("test-prf-key-32-bytes-long!!!!!!")
+	signerIDs := []int{0, 2}
+	sessionID := 1
+	domain, epoch, message := "checkpoint", uint64(9), []byte("block 1234")
+
+	round1Data := make(map[int]*Round1Data)
+	for _, id := range signerIDs {
+		round1Data[id] = signers[id].Round1(sessionID, prfKey, signerIDs)
+	}
+	round2Data := make(map[int]*Round2Data)
+	for _, id := range signerIDs {
+		data, err := signers[id].Round2Bundle(sessionID, domain, epoch, message, prfKey, signerIDs, round1Data)
+		if err != nil {
+			t.Fatalf("Round2Bundle(%d) failed: %v", id, err)
+		}
+		round2Data[id] = data
+	}
+	bundle, err := signers[2].SignBundle(domain, epoch, message, round2Data)
+	if err != nil {
+		t.Fatalf("SignBundle failed: %v", err)
+	}
+	if bundle.KeyDigest != VerificationKeyDigest(groupKey) {
+		t.Fatal("bundle digest differs from the group key digest")
+	}
+
+	// The verifier only sees the encoded bundle.
+	encoded, err := bundle.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary failed: %v", err)
+	}
+	decode := func() *SignedBundle {
+		t.Helper()
+		var decoded SignedBundle
+		if err := decoded.UnmarshalBinary(encoded); err != nil {
+			t.Fatalf("UnmarshalBinary failed: %v", err)
+		}
+		return &decoded
+	}
+	if decoded := decode(); !decoded.Verify() {
+		t.Fatal("decoded bundle does not verify")
+	}
+
+	_, otherKey, err := GenerateKeys(2, 3, nil)
+	if err != nil {
+		t.Fatalf("GenerateKeys failed: %v", err)
+	}
+	tests := []struct {
+		name   string
+		tamper func(b *SignedBundle)
+	}{
+		{name: "epoch", tamper: func(b *SignedBundle) { b.Epoch++ }},
+		{name: "domain", tamper: func(b *SignedBundle) { b.Domain = "vote" }},
+		{name: "message", tamper: func(b *SignedBundle) { b.Message = []byte("block 1235") }},
+		{name: "digest", tamper: func(b *SignedBundle) { b.KeyDigest[0] ^= 1 }},
+		{name: "group key", tamper: func(b *SignedBundle) {
+			b.GroupKey = otherKey
+			b.KeyDigest = VerificationKeyDigest(otherKey)
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := decode()
+			tt.tamper(b)
+			if b.Verify() {
+				t.Errorf("bundle with tampered %s verified", tt.name)
+			}
+		})
+	}
+
+	for _, data := range [][]byte{nil, encoded[:len(encoded)-1], append(append([]byte(nil), encoded...), 0)} {
+		var b SignedBundle
+		if err := b.UnmarshalBinary(data); !errors.Is(err, ErrInvalidEncoding) {
+			t.Errorf("UnmarshalBinary of %d bytes: expected ErrInvalidEncoding, got %v", len(data), err)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright (C) 2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package threshold
+
+import (
+	"bytes"
+	"crypto/subtle"
+
+	"github.com/luxfi/ringtail/sign"
+	"github.com/luxfi/ringtail/utils"
+
+	"github.com/luxfi/lattice/v7/ring"
+	"github.com/luxfi/lattice/v7/utils/structs"
+	"github.com/zeebo/blake3"
+)
+
+const contributionCommitTag = "RingtailContributionCommitV1"
+
+// CommitContribution returns a binding commitment to a party's key generation
+// contribution. A DKG coordinator collects every commitment before any party
+// reveals, so no party can choose its contribution after seeing the others'.
+func CommitContribution(contribution structs.Vector[ring.Poly]) []byte {
+	buf := new(bytes.Buffer)
+	if _, err := contribution.WriteTo(buf); err != nil {
+		return nil
+	}
+
+	hasher := blake3.New()
+	_, _ = hasher.Write([]byte(contributionCommitTag))
+	_, _ = hasher.Write(buf.Bytes())
+	return hasher.Sum(nil)
+}
+
+// VerifyContributionReveal checks that a revealed contribution opens the
+// commitment published for it by CommitContribution.
+func VerifyContributionReveal(commitment []byte, contribution structs.Vector[ring.Poly]) error {
+	expected := CommitContribution(contribution)
+	if len(commitment) == 0 || expected == nil || subtle.ConstantTimeCompare(commitment, expected) != 1 {
+		return ErrCommitmentMismatch
+	}
+	return nil
+}
+
+// AggregateContributions sums the parties' public contributions A·s_i, given in
+// NTT and Montgomery form like A itself, and rounds the sum exactly as the
+// dealer rounds b. The result can be compared with GroupKey.BTilde to check that
+// a distributed keygen produced the published group key.
+//
+// The sum omits the dealer's error term, which is public to no one. It is far
+// below the rounding granularity 2^Xi, so it moves a rounded coefficient by at
+// most one, and only when the coefficient sits near a rounding boundary:
+// compare each coefficient with BTilde modulo sign.QXi allowing a difference
+// of one, not for equality. Returns nil if a contribution does not have one
+// entry per row of A.
+func AggregateContributions(params *Params, A structs.Matrix[ring.Poly], contributions []structs.Vector[ring.Poly]) structs.Vector[ring.Poly] {
+	r := params.R
+	sum := utils.InitializeVector(r, len(A))
+	for _, contribution := range contributions {
+		if len(contribution) != len(A) {
+			return nil
+		}
+		utils.VectorAdd(r, sum, contribution, sum)
+	}
+
+	utils.ConvertVectorFromNTT(r, sum)
+	return utils.RoundVector(r, params.RXi, sum, sign.Xi)
+}
@@ -0,0 +1,100 @@
+// Copyright (C) 2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package threshold
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/luxfi/ringtail/sign"
+	"github.com/luxfi/ringtail/utils"
+
+	"github.com/luxfi/lattice/v7/ring"
+	"github.com/luxfi/lattice/v7/utils/sampling"
+	"github.com/luxfi/lattice/v7/utils/structs"
+)
+
+func TestContributionCommitReveal(t *testing.T) {
+	params, err := NewParams()
+	if err != nil {
+		t.Fatal(err)
+	}
+	prng, _ := sampling.NewPRNG()
+	sampler := ring.NewUniformSampler(prng, params.R)
+
+	contribution := utils.SamplePolyVector(params.R, sign.M, sampler, true, true)
+	other := utils.SamplePolyVector(params.R, sign.M, sampler, true, true)
+
+	commitment := CommitContribution(contribution)
+	if err := VerifyContributionReveal(commitment, contribution); err != nil {
+		t.Fatalf("honest reveal rejected: %v", err)
+	}
+
+	if err := VerifyContributionReveal(commitment, other); err != ErrCommitmentMismatch {
+		t.Errorf("different contribution: expected ErrCommitmentMismatch, got %v", err)
+	}
+
+	tampered := make(structs.Vector[ring.Poly], len(contribution))
+	for i := range contribution {
+		tampered[i] = *contribution[i].CopyNew()
+	}
+	tampered[0].Coeffs[0][0] ^= 1
+	if err := VerifyContributionReveal(commitment, tampered); err != ErrCommitmentMismatch {
+		t.Errorf("tampered contribution: expected ErrCommitmentMismatch, got %v", err)
+	}
+
+	if err := VerifyContributionReveal(nil, contribution); err != ErrCommitmentMismatch {
+		t.Errorf("missing commitment: expected ErrCommitmentMismatch, got %v", err)
+	}
+}
+
+func TestAggregateContributions(t *testing.T) {
+	seed := bytes.Repeat([]byte{0x42}, sign.KeySize)
+	shares, groupKey, err := GenerateKeys(2, 3, bytes.NewReader(seed))
+	if err != nil {
+		t.Fatalf("GenerateKeys failed: %v", err)
+	}
+
+	var contributions []structs.Vector[ring.Poly]
+	for _, share := range shares {
+		contributions = append(contributions, shareContribution(groupKey, share))
+	}
+
+	bTilde := AggregateContributions(groupKey.Params, groupKey.A, contributions)
+	if len(bTilde) != len(groupKey.BTilde) {
+		t.Fatalf("aggregated key has %d entries, want %d", len(bTilde), len(groupKey.BTilde))
+	}
+	for i := range bTilde {
+		if j, ok := withinRounding(bTilde[i], groupKey.BTilde[i], sign.QXi); !ok {
+			t.Errorf("aggregated BTilde[%d] coefficient %d is more than one rounding step from the group key", i, j)
+		}
+	}
+
+	if AggregateContributions(groupKey.Params, groupKey.A, []structs.Vector[ring.Poly]{contributions[0][:1]}) != nil {
+		t.Error("expected nil for a contribution with the wrong length")
+	}
+}
+
+// shareContribution computes A·(λ_i·s_i) for a key share.
+func shareContribution(groupKey *GroupKey, share *KeyShare) structs.Vector[ring.Poly] {
+	r := groupKey.Params.R
+	weighted := utils.InitializeVector(r, len(share.SkShare))
+	utils.VectorPolyMul(r, share.SkShare, share.Lambda, weighted)
+	contribution := utils.InitializeVector(r, len(groupKey.A))
+	utils.MatrixVectorMul(r, groupKey.A, weighted, contribution)
+	return contribution
+}
+
+// withinRounding reports whether every coefficient of a and b differs by at
+// most one modulo q, the error the dealer's noise can add through rounding.
+// On failure it returns the first coefficient that does not.
+func withinRounding(a, b ring.Poly, q uint64) (int, bool) {
+	for j := range a.Coeffs[0] {
+		d := (a.Coeffs[0][j] + q - b.Coeffs[0][j]) % q
+		if d > 1 && d != q-1 {
+			return j, false
+		}
+	}
+	return 0, true
+}
@@ -0,0 +1,176 @@
+// Copyright (C) 2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package threshold
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/luxfi/ringtail/sign"
+	"github.com/luxfi/ringtail/utils"
+
+	"github.com/luxfi/lattice/v7/ring"
+	"github.com/luxfi/lattice/v7/utils/structs"
+	"github.com/zeebo/blake3"
+)
+
+// dealerCombineTag separates combined seeds and MAC keys from their inputs.
+const dealerCombineTag = "RingtailDealerCombineV1"
+
+var (
+	ErrInvalidMatrix  = errors.New("public matrix has wrong dimensions")
+	ErrDealerMismatch = errors.New("dealer shares do not match")
+)
+
+// DealShares is GenerateKeys under a public matrix A agreed on beforehand, so
+// the shares of several dealers can be combined with CombineDealerShares.
+func DealShares(A structs.Matrix[ring.Poly], t, n int, randSource io.Reader) ([]*KeyShare, *GroupKey, error) {
+	if len(A) != sign.M {
+		return nil, nil, fmt.Errorf("%w: %d rows", ErrInvalidMatrix, len(A))
+	}
+	for _, row := range A {
+		if len(row) != sign.N {
+			return nil, nil, fmt.Errorf("%w: %d columns", ErrInvalidMatrix, len(row))
+		}
+	}
+	weights, err := unitWeights(t, n)
+	if err != nil {
+		return nil, nil, err
+	}
+	return generateKeys(nil, weights, t, A, randSource)
+}
+
+// CombineDealerShares adds up the shares that independent dealers dealt with
+// DealShares under the same A, weights and threshold. The combined secret is
+// the sum of the dealers' secrets, so no single dealer knows it; pairwise
+// seeds and MAC keys are hashed from every dealer's, for the same reason.
+// dealerShares[d] holds the shares of dealer d, indexed by party.
+//
+// This is a step toward dealerless setup: every dealer still learns all the
+// shares it deals, so the combined key is secret as long as one dealer is honest.
+func CombineDealerShares(dealerShares [][]*KeyShare) ([]*KeyShare, *GroupKey, error) {
+	if len(dealerShares) < 2 {
+		return nil, nil, fmt.Errorf("%w: need at least 2 dealers", ErrInsufficientData)
+	}
+	if err := checkDealers(dealerShares); err != nil {
+		return nil, nil, err
+	}
+
+	first := dealerShares[0][0].GroupKey
+	params := first.Params
+	r := params.R
+
+	b := utils.InitializeVector(r, len(first.b))
+	slotKeys := make([]structs.Vector[ring.Poly], len(first.SlotKeys))
+	for k := range slotKeys {
+		slotKeys[k] = utils.InitializeVector(r, len(first.SlotKeys[k]))
+	}
+	for _, shares := range dealerShares {
+		gk := shares[0].GroupKey
+		utils.VectorAdd(r, b, gk.b, b)
+		for k := range slotKeys {
+			utils.VectorAdd(r, slotKeys[k], gk.SlotKeys[k], slotKeys[k])
+		}
+	}
+	groupKey := &GroupKey{
+		A:         first.A,
+		BTilde:    utils.RoundVector(r, params.RXi, b, sign.Xi),
+		Params:    params,
+		Weights:   append([]int(nil), first.Weights...),
+		Threshold: first.Threshold,
+		SlotKeys:  slotKeys,
+		b:         b,
+	}
+
+	n := len(dealerShares[0])
+	seeds := make(map[int][][]byte, n)
+	for i := 0; i < n; i++ {
+		seeds[i] = make([][]byte, n)
+		for j := 0; j < n; j++ {
+			seeds[i][j] = combineDealerKeys(dealerShares, func(shares []*KeyShare) []byte { return shares[0].Seeds[i][j] })
+		}
+	}
+
+	combined := make([]*KeyShare, n)
+	for i := 0; i < n; i++ {
+		base := dealerShares[0][i]
+		slotShares := make([]structs.Vector[ring.Poly], len(base.SlotShares))
+		for k := range slotShares {
+			slotShares[k] = utils.InitializeVector(r, len(base.SlotShares[k]))
+			for _, shares := range dealerShares {
+				utils.VectorAdd(r, slotShares[k], shares[i].SlotShares[k], slotShares[k])
+			}
+		}
+		macKeys := make(map[int][]byte, len(base.MACKeys))
+		for j := range base.MACKeys {
+			macKeys[j] = combineDealerKeys(dealerShares, func(shares []*KeyShare) []byte { return shares[i].MACKeys[j] })
+		}
+		combined[i] = &KeyShare{
+			Index:      i,
+			SkShare:    slotShares[0],
+			Seeds:      seeds,
+			MACKeys:    macKeys,
+			Lambda:     base.Lambda,
+			GroupKey:   groupKey,
+			Slots:      append([]int(nil), base.Slots...),
+			SlotShares: slotShares,
+		}
+	}
+	return combined, groupKey, nil
+}
+
+// checkDealers verifies that every dealer dealt a full set of shares under the
+// same matrix, weights and threshold.
+func checkDealers(dealerShares [][]*KeyShare) error {
+	first := dealerShares[0]
+	if len(first) == 0 {
+		return fmt.Errorf("%w: dealer 0 has no shares", ErrDealerMismatch)
+	}
+	ref := first[0].GroupKey
+	r := ref.Params.R
+	for d, shares := range dealerShares {
+		if len(shares) != len(first) {
+			return fmt.Errorf("%w: dealer %d has %d shares, dealer 0 has %d", ErrDealerMismatch, d, len(shares), len(first))
+		}
+		gk := shares[0].GroupKey
+		if gk.b == nil {
+			return fmt.Errorf("%w: dealer %d has no unrounded public key", ErrDealerMismatch, d)
+		}
+		if !slices.Equal(gk.Weights, ref.Weights) || gk.Threshold != ref.Threshold {
+			return fmt.Errorf("%w: dealer %d uses different weights or threshold", ErrDealerMismatch, d)
+		}
+		if len(gk.A) != len(ref.A) {
+			return fmt.Errorf("%w: dealer %d uses a different A", ErrDealerMismatch, d)
+		}
+		for i := range gk.A {
+			if len(gk.A[i]) != len(ref.A[i]) {
+				return fmt.Errorf("%w: dealer %d uses a different A", ErrDealerMismatch, d)
+			}
+			for j := range gk.A[i] {
+				if !r.Equal(gk.A[i][j], ref.A[i][j]) {
+					return fmt.Errorf("%w: dealer %d uses a different A", ErrDealerMismatch, d)
+				}
+			}
+		}
+		for i, share := range shares {
+			if share.Index != i || share.GroupKey != gk {
+				return fmt.Errorf("%w: dealer %d share %d is out of place", ErrDealerMismatch, d, i)
+			}
+		}
+	}
+	return nil
+}
+
+// combineDealerKeys hashes the key each dealer dealt for one entry of the seed
+// or MAC key tables, as picked from that dealer's shares by key.
+func combineDealerKeys(dealerShares [][]*KeyShare, key func(shares []*KeyShare) []byte) []byte {
+	hasher := blake3.New()
+	_, _ = hasher.Write([]byte(dealerCombineTag))
+	for _, shares := range dealerShares {
+		_, _ = hasher.Write(key(shares))
+	}
+	return hasher.Sum(nil)[:sign.KeySize]
+}
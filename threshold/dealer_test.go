@@ -0,0 +1,86 @@
+// Copyright (C) 2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package threshold
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestCombineDealerShares(t *testing.T) {
+	_, setup, err := GenerateKeys(2, 3, nil)
+	if err != nil {
+		t.Fatalf("GenerateKeys failed: %v", err)
+	}
+
+	dealers := make([][]*KeyShare, 2)
+	keys := make([]*GroupKey, 2)
+	for d := range dealers {
+		source := bytes.NewReader(bytes.Repeat([]byte{byte(d + 1)}, 32))
+		dealers[d], keys[d], err = DealShares(setup.A, 2, 3, source)
+		if err != nil {
+			t.Fatalf("DealShares(%d) failed: %v", d, err)
+		}
+	}
+
+	shares, groupKey, err := CombineDealerShares(dealers)
+	if err != nil {
+		t.Fatalf("CombineDealerShares failed: %v", err)
+	}
+	for d, key := range keys {
+		same := true
+		for i := range key.BTilde {
+			same = same && groupKey.Params.RXi.Equal(key.BTilde[i], groupKey.BTilde[i])
+		}
+		if same {
+			t.Errorf("combined key equals dealer %d's key", d)
+		}
+	}
+	if !bytes.Equal(shares[0].MACKeys[1], shares[1].MACKeys[0]) {
+		t.Error("combined MAC keys are not symmetric")
+	}
+	if bytes.Equal(shares[0].MACKeys[1], dealers[0][0].MACKeys[1]) {
+		t.Error("combined MAC key equals a dealer's MAC key")
+	}
+
+	for i, signerIDs := range [][]int{{0, 1}, {1, 2}} {
+		message := "combined dealers"
+		sig, err := signSession(newSigners(shares), signerIDs, i+1, message)
+		if err != nil {
+			t.Fatalf("signing with %v failed: %v", signerIDs, err)
+		}
+		if !Verify(groupKey, message, sig) {
+			t.Errorf("signature by %v did not verify under the combined key", signerIDs)
+		}
+		if Verify(keys[0], message, sig) {
+			t.Errorf("signature by %v verified under a single dealer's key", signerIDs)
+		}
+	}
+
+	otherA, _, err := GenerateKeys(2, 3, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		dealers [][]*KeyShare
+		wantErr error
+	}{
+		{name: "single dealer", dealers: dealers[:1], wantErr: ErrInsufficientData},
+		{name: "different A", dealers: [][]*KeyShare{dealers[0], otherA}, wantErr: ErrDealerMismatch},
+		{name: "missing share", dealers: [][]*KeyShare{dealers[0], dealers[1][:2]}, wantErr: ErrDealerMismatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := CombineDealerShares(tt.dealers); !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	if _, _, err := DealShares(setup.A[:2], 2, 3, nil); !errors.Is(err, ErrInvalidMatrix) {
+		t.Errorf("expected ErrInvalidMatrix, got %v", err)
+	}
+}
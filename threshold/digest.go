@@ -0,0 +1,98 @@
+// Copyright (C) 2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package threshold
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/binary"
+
+	"github.com/luxfi/ringtail/utils"
+
+	"github.com/zeebo/blake3"
+)
+
+const (
+	verificationKeyDigestTag = "RingtailVerificationKeyV1"
+	groupFingerprintTag      = "RingtailGroupFingerprintV1"
+)
+
+// VerificationKeyDigest returns a 32-byte commitment to the group key that a
+// light client can pin. It binds the ring parameters as well as A and BTilde,
+// so keys over different rings never share a digest. Returns the zero digest
+// for a nil or incomplete group key.
+func VerificationKeyDigest(groupKey *GroupKey) [32]byte {
+	var digest [32]byte
+	if groupKey == nil || groupKey.Params == nil || groupKey.A == nil || groupKey.BTilde == nil {
+		return digest
+	}
+
+	buf := new(bytes.Buffer)
+	buf.WriteString(verificationKeyDigestTag)
+	r := groupKey.Params.R
+	_ = binary.Write(buf, utils.TranscriptByteOrder(), uint64(r.N()))
+	_ = binary.Write(buf, utils.TranscriptByteOrder(), r.Modulus().Uint64())
+	if _, err := groupKey.A.WriteTo(buf); err != nil {
+		return digest
+	}
+	if _, err := groupKey.BTilde.WriteTo(buf); err != nil {
+		return digest
+	}
+
+	hasher := blake3.New()
+	_, _ = hasher.Write(buf.Bytes())
+	copy(digest[:], hasher.Sum(nil))
+	return digest
+}
+
+// VerifyWithDigest verifies sig like Verify, and additionally rejects it
+// unless groupKey matches the pinned digest expected.
+func VerifyWithDigest(expected [32]byte, groupKey *GroupKey, message string, sig *Signature) bool {
+	digest := VerificationKeyDigest(groupKey)
+	if digest == ([32]byte{}) || subtle.ConstantTimeCompare(digest[:], expected[:]) != 1 {
+		return false
+	}
+	return Verify(groupKey, message, sig)
+}
+
+// Fingerprint returns a 32-byte commitment to the group key and its
+// membership: the VerificationKeyDigest followed by the public identifier of
+// every party in Members, in party order. Two groups with the same key but
+// different members have different fingerprints, so pinning the fingerprint
+// with VerifyWithFingerprint ties a signature to the membership as well.
+// Returns the zero fingerprint where VerificationKeyDigest is zero.
+func (gk *GroupKey) Fingerprint() [32]byte {
+	var fingerprint [32]byte
+	digest := VerificationKeyDigest(gk)
+	if digest == ([32]byte{}) {
+		return fingerprint
+	}
+
+	buf := new(bytes.Buffer)
+	buf.WriteString(groupFingerprintTag)
+	buf.Write(digest[:])
+	_ = binary.Write(buf, utils.TranscriptByteOrder(), uint64(len(gk.Members)))
+	for _, member := range gk.Members {
+		_ = binary.Write(buf, utils.TranscriptByteOrder(), uint64(len(member)))
+		buf.Write(member)
+	}
+
+	hasher := blake3.New()
+	_, _ = hasher.Write(buf.Bytes())
+	copy(fingerprint[:], hasher.Sum(nil))
+	return fingerprint
+}
+
+// VerifyWithFingerprint verifies sig like Verify, and additionally rejects it
+// unless groupKey, members included, matches the pinned fingerprint expected.
+func VerifyWithFingerprint(expected [32]byte, groupKey *GroupKey, message string, sig *Signature) bool {
+	if groupKey == nil {
+		return false
+	}
+	fingerprint := groupKey.Fingerprint()
+	if fingerprint == ([32]byte{}) || subtle.ConstantTimeCompare(fingerprint[:], expected[:]) != 1 {
+		return false
+	}
+	return Verify(groupKey, message, sig)
+}
@@ -0,0 +1,112 @@
+// Copyright (C) 2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package threshold
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestVerifyWithDigest(t *testing.T) {
+	shares, groupKey, err := GenerateKeys(2, 3, nil)
+	if err != nil {
+		t.Fatalf("GenerateKeys failed: %v", err)
+	}
+	_, otherGroupKey, err := GenerateKeys(2, 3, nil)
+	if err != nil {
+		t.Fatalf("GenerateKeys failed: %v", err)
+	}
+
+	digest := VerificationKeyDigest(groupKey)
+	if digest != VerificationKeyDigest(groupKey) {
+		t.Fatal("VerificationKeyDigest is not deterministic")
+	}
+	otherDigest := VerificationKeyDigest(otherGroupKey)
+	if digest == otherDigest {
+		t.Fatal("distinct group keys share a digest")
+	}
+	if VerificationKeyDigest(nil) != ([32]byte{}) {
+		t.Error("nil group key should have the zero digest")
+	}
+
+	message := "pinned group"
+	sig, err := signSession(newSigners(shares), []int{0, 1, 2}, 1, message)
+	if err != nil {
+		t.Fatalf("signing failed: %v", err)
+	}
+
+	if !VerifyWithDigest(digest, groupKey, message, sig) {
+		t.Error("signature rejected under the pinned digest")
+	}
+	if !Verify(groupKey, message, sig) {
+		t.Fatal("signature is not otherwise valid")
+	}
+	if VerifyWithDigest(otherDigest, groupKey, message, sig) {
+		t.Error("signature accepted against the wrong group key digest")
+	}
+	if VerifyWithDigest([32]byte{}, nil, message, sig) {
+		t.Error("signature accepted without a group key")
+	}
+}
+
+func TestGroupFingerprint(t *testing.T) {
+	seed := bytes.Repeat([]byte{7}, 32)
+	members := [][]byte{[]byte("validator-a"), []byte("validator-b"), []byte("validator-c")}
+	shares, groupKey, err := GenerateKeysWithMembers(2, members, bytes.NewReader(seed))
+	if err != nil {
+		t.Fatalf("GenerateKeysWithMembers failed: %v", err)
+	}
+	if len(shares) != len(members) || len(groupKey.Members) != len(members) {
+		t.Fatalf("got %d shares and %d members, want %d", len(shares), len(groupKey.Members), len(members))
+	}
+
+	swapped := [][]byte{members[0], members[1], []byte("validator-d")}
+	_, otherGroupKey, err := GenerateKeysWithMembers(2, swapped, bytes.NewReader(seed))
+	if err != nil {
+		t.Fatalf("GenerateKeysWithMembers failed: %v", err)
+	}
+	if VerificationKeyDigest(groupKey) != VerificationKeyDigest(otherGroupKey) {
+		t.Fatal("same seed produced different group keys")
+	}
+
+	fingerprint := groupKey.Fingerprint()
+	if fingerprint != groupKey.Fingerprint() {
+		t.Fatal("Fingerprint is not deterministic")
+	}
+	if fingerprint == otherGroupKey.Fingerprint() {
+		t.Error("group keys with different members share a fingerprint")
+	}
+	reordered := *groupKey
+	reordered.Members = [][]byte{members[1], members[0], members[2]}
+	if fingerprint == reordered.Fingerprint() {
+		t.Error("reordering the members kept the fingerprint")
+	}
+	unbound := *groupKey
+	unbound.Members = nil
+	if fingerprint == unbound.Fingerprint() {
+		t.Error("group key without members shares the fingerprint")
+	}
+
+	message := "membership"
+	sig, err := signSession(newSigners(shares), []int{0, 1}, 1, message)
+	if err != nil {
+		t.Fatalf("signing failed: %v", err)
+	}
+	if !VerifyWithFingerprint(fingerprint, groupKey, message, sig) {
+		t.Error("signature rejected under the pinned fingerprint")
+	}
+	if VerifyWithFingerprint(fingerprint, otherGroupKey, message, sig) {
+		t.Error("signature accepted under a group with different members")
+	}
+
+	for name, bad := range map[string][][]byte{
+		"empty":     {[]byte("a"), nil, []byte("c")},
+		"duplicate": {[]byte("a"), []byte("b"), []byte("a")},
+	} {
+		if _, _, err := GenerateKeysWithMembers(2, bad, nil); !errors.Is(err, ErrInvalidMember) {
+			t.Errorf("%s: expected ErrInvalidMember, got %v", name, err)
+		}
+	}
+}
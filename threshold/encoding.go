@@ -0,0 +1,422 @@
+// Copyright (C) 2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package threshold
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/luxfi/ringtail/sign"
+	"github.com/luxfi/ringtail/utils"
+
+	"github.com/luxfi/lattice/v7/ring"
+	"github.com/luxfi/lattice/v7/utils/structs"
+)
+
+// round1DataVersion is the first byte of every encoded Round1Data.
+const round1DataVersion = 2
+
+// signatureVersion is the first byte of every encoded Signature.
+const signatureVersion = 1
+
+// groupKeyVersion is the first byte of every encoded GroupKey.
+const groupKeyVersion = 1
+
+// Lengths of the fixed headers in front of the lattice encoding of a
+// Signature and a GroupKey.
+const (
+	signatureHeaderSize = 1 + 4 + 8 + 4 + 4
+	groupKeyHeaderSize  = 1 + 4 + 8
+)
+
+var (
+	// ErrInvalidEncoding is returned when decoding malformed round data.
+	ErrInvalidEncoding = errors.New("invalid round data encoding")
+	// ErrModulusMismatch is returned when encoded round data was produced
+	// for a ring with a different degree or modulus than the target ring.
+	ErrModulusMismatch = errors.New("encoded ring does not match target ring")
+	// ErrIncompleteGroupKey is returned when encoding a group key without
+	// its parameters, A or BTilde.
+	ErrIncompleteGroupKey = errors.New("group key is incomplete")
+)
+
+// MarshalBinary encodes a Round 1 broadcast as
+//
+//	version (1 byte) || N (u32) || Q (u64) || PartyID (u32) ||
+//	D (lattice encoding) ||
+//	MAC count (u32) || { recipient (u32) || MAC length (u32) || MAC }
+//
+// with MACs in ascending recipient order and integers in utils.WireByteOrder,
+// so equal data always encodes to equal bytes. N and Q are those of the ring
+// the signing code runs over, so bytes from another parameter set are rejected
+// on decoding instead of being misread.
+func (rd *Round1Data) MarshalBinary() ([]byte, error) {
+	buf := bytes.NewBuffer(make([]byte, 0, rd.SerializedSize()))
+	buf.WriteByte(round1DataVersion)
+	writeUint32(buf, 1<<sign.LogN)
+	writeUint64(buf, sign.Q)
+	writeUint32(buf, uint32(rd.PartyID))
+	if _, err := rd.D.WriteTo(buf); err != nil {
+		return nil, err
+	}
+	recipients := make([]int, 0, len(rd.MACs))
+	for j := range rd.MACs {
+		recipients = append(recipients, j)
+	}
+	sort.Ints(recipients)
+	writeUint32(buf, uint32(len(recipients)))
+	for _, j := range recipients {
+		writeUint32(buf, uint32(j))
+		writeUint32(buf, uint32(len(rd.MACs[j])))
+		buf.Write(rd.MACs[j])
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary decodes data produced by MarshalBinary for the ring the
+// signing code runs over.
+func (rd *Round1Data) UnmarshalBinary(data []byte) error {
+	return rd.unmarshal(1<<sign.LogN, sign.Q, data)
+}
+
+// UnmarshalBinaryFor decodes data produced by MarshalBinary, failing with
+// ErrModulusMismatch unless it was encoded for the degree and modulus of r.
+func (rd *Round1Data) UnmarshalBinaryFor(r *ring.Ring, data []byte) error {
+	return rd.unmarshal(r.N(), r.Modulus().Uint64(), data)
+}
+
+func (rd *Round1Data) unmarshal(n int, q uint64, data []byte) error {
+	reader := bufio.NewReader(bytes.NewReader(data))
+
+	version, err := reader.ReadByte()
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidEncoding, err)
+	}
+	if version != round1DataVersion {
+		return fmt.Errorf("%w: unsupported version %d", ErrInvalidEncoding, version)
+	}
+	encodedN, err := readUint32(reader)
+	if err != nil {
+		return err
+	}
+	encodedQ, err := readUint64(reader)
+	if err != nil {
+		return err
+	}
+	if int(encodedN) != n || encodedQ != q {
+		return fmt.Errorf("%w: encoded N=%d Q=%d, target N=%d Q=%d", ErrModulusMismatch, encodedN, encodedQ, n, q)
+	}
+	partyID, err := readUint32(reader)
+	if err != nil {
+		return err
+	}
+	var D structs.Matrix[ring.Poly]
+	if _, err := D.ReadFrom(reader); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidEncoding, err)
+	}
+	count, err := readUint32(reader)
+	if err != nil {
+		return err
+	}
+	MACs := make(map[int][]byte)
+	for i := uint32(0); i < count; i++ {
+		recipient, err := readUint32(reader)
+		if err != nil {
+			return err
+		}
+		length, err := readUint32(reader)
+		if err != nil {
+			return err
+		}
+		if int(length) > len(data) {
+			return fmt.Errorf("%w: MAC length %d exceeds input", ErrInvalidEncoding, length)
+		}
+		mac := make([]byte, length)
+		if _, err := io.ReadFull(reader, mac); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidEncoding, err)
+		}
+		MACs[int(recipient)] = mac
+	}
+	if _, err := reader.ReadByte(); err != io.EOF {
+		return fmt.Errorf("%w: trailing bytes", ErrInvalidEncoding)
+	}
+
+	rd.PartyID = int(partyID)
+	rd.D = D
+	rd.MACs = MACs
+	return nil
+}
+
+// SerializedSize returns the exact length of MarshalBinary's output, for
+// sizing network buffers and rate limits before a Round 1 broadcast.
+func (rd *Round1Data) SerializedSize() int {
+	size := 1 + 4 + 8 + 4 + rd.D.BinarySize() + 4
+	for _, mac := range rd.MACs {
+		size += 4 + 4 + len(mac)
+	}
+	return size
+}
+
+// MarshalBinary encodes a signature as
+//
+//	version (1 byte) || N (u32) || Q (u64) ||
+//	Z length (u32) || Delta length (u32) ||
+//	c || Z || Delta (lattice encoding)
+//
+// with c as a vector of one polynomial and integers in utils.WireByteOrder.
+// The lengths come ahead of the polynomials so a decoder can reject a
+// malformed signature before allocating for it.
+func (sig *Signature) MarshalBinary() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	buf.WriteByte(signatureVersion)
+	writeUint32(buf, 1<<sign.LogN)
+	writeUint64(buf, sign.Q)
+	writeUint32(buf, uint32(len(sig.Z)))
+	writeUint32(buf, uint32(len(sig.Delta)))
+	for _, v := range []structs.Vector[ring.Poly]{{sig.C}, sig.Z, sig.Delta} {
+		if _, err := v.WriteTo(buf); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary decodes a signature produced by MarshalBinary for the ring
+// the signing code runs over. It rejects Z and Delta lengths other than
+// sign.N and sign.M.
+func (sig *Signature) UnmarshalBinary(data []byte) error {
+	reader := bufio.NewReader(bytes.NewReader(data))
+
+	version, err := reader.ReadByte()
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidEncoding, err)
+	}
+	if version != signatureVersion {
+		return fmt.Errorf("%w: unsupported signature version %d", ErrInvalidEncoding, version)
+	}
+	encodedN, err := readUint32(reader)
+	if err != nil {
+		return err
+	}
+	encodedQ, err := readUint64(reader)
+	if err != nil {
+		return err
+	}
+	if encodedN != 1<<sign.LogN || encodedQ != sign.Q {
+		return fmt.Errorf("%w: encoded N=%d Q=%d, target N=%d Q=%d", ErrModulusMismatch, encodedN, encodedQ, 1<<sign.LogN, uint64(sign.Q))
+	}
+	zLen, err := readUint32(reader)
+	if err != nil {
+		return err
+	}
+	deltaLen, err := readUint32(reader)
+	if err != nil {
+		return err
+	}
+	if zLen != sign.N || deltaLen != sign.M {
+		return fmt.Errorf("%w: signature lengths Z=%d Delta=%d, want %d and %d", ErrInvalidEncoding, zLen, deltaLen, sign.N, sign.M)
+	}
+
+	var c, z, delta structs.Vector[ring.Poly]
+	for _, v := range []*structs.Vector[ring.Poly]{&c, &z, &delta} {
+		if _, err := v.ReadFrom(reader); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidEncoding, err)
+		}
+	}
+	if len(c) != 1 || len(z) != int(zLen) || len(delta) != int(deltaLen) {
+		return fmt.Errorf("%w: vector lengths do not match the header", ErrInvalidEncoding)
+	}
+	if _, err := reader.ReadByte(); err != io.EOF {
+		return fmt.Errorf("%w: trailing bytes", ErrInvalidEncoding)
+	}
+
+	sig.C = c[0]
+	sig.Z = z
+	sig.Delta = delta
+	return nil
+}
+
+// MarshalBinaryWithOrder is MarshalBinary with the uint64 words of the lattice
+// encoding, lengths and coefficients, in order rather than
+// utils.CoefficientByteOrder, for peers that chose the other byte order. The
+// header is unchanged.
+func (sig *Signature) MarshalBinaryWithOrder(order binary.ByteOrder) ([]byte, error) {
+	data, err := sig.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	if err := utils.ReorderWords(data[signatureHeaderSize:], utils.CoefficientByteOrder(), order); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// UnmarshalBinaryWithOrder decodes a signature produced by
+// MarshalBinaryWithOrder with the same order.
+func (sig *Signature) UnmarshalBinaryWithOrder(data []byte, order binary.ByteOrder) error {
+	data, err := reorderLattice(data, signatureHeaderSize, order)
+	if err != nil {
+		return err
+	}
+	return sig.UnmarshalBinary(data)
+}
+
+// Bytes encodes the verification part of the group key as
+//
+//	version (1 byte) || N (u32) || Q (u64) || A || BTilde (lattice encoding)
+//
+// with integers in utils.WireByteOrder. Equal keys always encode to equal
+// bytes, so parties can hash the encoding to agree on the key. Weights,
+// Threshold and SlotKeys are not encoded.
+func (gk *GroupKey) Bytes() ([]byte, error) {
+	if gk == nil || gk.Params == nil || len(gk.A) == 0 || len(gk.BTilde) == 0 {
+		return nil, ErrIncompleteGroupKey
+	}
+	r := gk.Params.R
+	buf := new(bytes.Buffer)
+	buf.WriteByte(groupKeyVersion)
+	writeUint32(buf, uint32(r.N()))
+	writeUint64(buf, r.Modulus().Uint64())
+	if _, err := gk.A.WriteTo(buf); err != nil {
+		return nil, err
+	}
+	if _, err := gk.BTilde.WriteTo(buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// ParseGroupKey decodes a group key produced by GroupKey.Bytes. Its Params are
+// rebuilt from the preset with the encoded N and Q, failing with
+// ErrModulusMismatch if there is none.
+func ParseGroupKey(data []byte) (*GroupKey, error) {
+	reader := bufio.NewReader(bytes.NewReader(data))
+
+	version, err := reader.ReadByte()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidEncoding, err)
+	}
+	if version != groupKeyVersion {
+		return nil, fmt.Errorf("%w: unsupported group key version %d", ErrInvalidEncoding, version)
+	}
+	encodedN, err := readUint32(reader)
+	if err != nil {
+		return nil, err
+	}
+	encodedQ, err := readUint64(reader)
+	if err != nil {
+		return nil, err
+	}
+	preset, err := presetForRing(encodedN, encodedQ)
+	if err != nil {
+		return nil, err
+	}
+
+	var A structs.Matrix[ring.Poly]
+	if _, err := A.ReadFrom(reader); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidEncoding, err)
+	}
+	var bTilde structs.Vector[ring.Poly]
+	if _, err := bTilde.ReadFrom(reader); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidEncoding, err)
+	}
+	if len(A) != preset.M || len(bTilde) != preset.M {
+		return nil, fmt.Errorf("%w: A has %d rows and BTilde %d entries, want %d", ErrInvalidEncoding, len(A), len(bTilde), preset.M)
+	}
+	for _, row := range A {
+		if len(row) != preset.N {
+			return nil, fmt.Errorf("%w: A row has %d columns, want %d", ErrInvalidEncoding, len(row), preset.N)
+		}
+	}
+	if _, err := reader.ReadByte(); err != io.EOF {
+		return nil, fmt.Errorf("%w: trailing bytes", ErrInvalidEncoding)
+	}
+
+	params, err := NewParamsFromPreset(preset)
+	if err != nil {
+		return nil, err
+	}
+	return &GroupKey{A: A, BTilde: bTilde, Params: params}, nil
+}
+
+// BytesWithOrder is Bytes with the uint64 words of the lattice encoding of A
+// and BTilde in order rather than utils.CoefficientByteOrder. The header is
+// unchanged.
+func (gk *GroupKey) BytesWithOrder(order binary.ByteOrder) ([]byte, error) {
+	data, err := gk.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	if err := utils.ReorderWords(data[groupKeyHeaderSize:], utils.CoefficientByteOrder(), order); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// ParseGroupKeyWithOrder decodes a group key produced by BytesWithOrder with
+// the same order.
+func ParseGroupKeyWithOrder(data []byte, order binary.ByteOrder) (*GroupKey, error) {
+	data, err := reorderLattice(data, groupKeyHeaderSize, order)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGroupKey(data)
+}
+
+// reorderLattice returns a copy of data with the lattice encoding after its
+// header converted from order to utils.CoefficientByteOrder. Data shorter
+// than the header is returned as is, for the decoder to reject.
+func reorderLattice(data []byte, headerSize int, order binary.ByteOrder) ([]byte, error) {
+	if len(data) < headerSize {
+		return data, nil
+	}
+	data = bytes.Clone(data)
+	if err := utils.ReorderWords(data[headerSize:], order, utils.CoefficientByteOrder()); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidEncoding, err)
+	}
+	return data, nil
+}
+
+// presetForRing returns the preset whose main ring has degree n and modulus q.
+func presetForRing(n uint32, q uint64) (*sign.Preset, error) {
+	for _, p := range sign.Presets() {
+		if 1<<p.LogN == int(n) && p.Q == q {
+			return &p, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: no preset has N=%d Q=%d", ErrModulusMismatch, n, q)
+}
+
+func writeUint32(buf *bytes.Buffer, v uint32) {
+	var b [4]byte
+	utils.WireByteOrder().PutUint32(b[:], v)
+	buf.Write(b[:])
+}
+
+func writeUint64(buf *bytes.Buffer, v uint64) {
+	var b [8]byte
+	utils.WireByteOrder().PutUint64(b[:], v)
+	buf.Write(b[:])
+}
+
+func readUint32(reader io.Reader) (uint32, error) {
+	var b [4]byte
+	if _, err := io.ReadFull(reader, b[:]); err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrInvalidEncoding, err)
+	}
+	return utils.WireByteOrder().Uint32(b[:]), nil
+}
+
+func readUint64(reader io.Reader) (uint64, error) {
+	var b [8]byte
+	if _, err := io.ReadFull(reader, b[:]); err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrInvalidEncoding, err)
+	}
+	return utils.WireByteOrder().Uint64(b[:]), nil
+}
@@ -0,0 +1,295 @@
+// Copyright (C) 2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package threshold
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"github.com/luxfi/ringtail/utils"
+
+	"github.com/luxfi/lattice/v7/ring"
+)
+
+func TestRound1DataEncoding(t *testing.T) {
+	shares, groupKey, err := GenerateKeys(2, 3, nil)
+	if err != nil {
+		t.Fatalf("GenerateKeys failed: %v", err)
+	}
+	r := groupKey.Params.R
+	prfKey := []byte("test-prf-key-32-bytes-long!!!!!!")
+	data := NewSigner(shares[1]).Round1(1, prfKey, []int{0, 1, 2})
+
+	encoded, err := data.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary failed: %v", err)
+	}
+	if got := data.SerializedSize(); got != len(encoded) {
+		t.Fatalf("SerializedSize() = %d, encoded length %d", got, len(encoded))
+	}
+	t.Logf("Round 1 broadcast: %d bytes", len(encoded))
+
+	again, err := data.MarshalBinary()
+	if err != nil || !bytes.Equal(encoded, again) {
+		t.Fatal("MarshalBinary is not deterministic")
+	}
+
+	var decoded Round1Data
+	if err := decoded.UnmarshalBinary(encoded); err != nil {
+		t.Fatalf("UnmarshalBinary failed: %v", err)
+	}
+	if decoded.PartyID != data.PartyID {
+		t.Errorf("PartyID = %d, want %d", decoded.PartyID, data.PartyID)
+	}
+	if len(decoded.D) != len(data.D) {
+		t.Fatalf("D has %d rows, want %d", len(decoded.D), len(data.D))
+	}
+	for i := range data.D {
+		for j := range data.D[i] {
+			if !r.Equal(decoded.D[i][j], data.D[i][j]) {
+				t.Fatalf("D[%d][%d] differs after decoding", i, j)
+			}
+		}
+	}
+	if len(decoded.MACs) != len(data.MACs) {
+		t.Fatalf("decoded %d MACs, want %d", len(decoded.MACs), len(data.MACs))
+	}
+	for j, mac := range data.MACs {
+		if !bytes.Equal(decoded.MACs[j], mac) {
+			t.Errorf("MAC for party %d differs after decoding", j)
+		}
+	}
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{name: "empty", data: nil},
+		{name: "unknown version", data: append([]byte{round1DataVersion + 1}, encoded[1:]...)},
+		{name: "truncated", data: encoded[:len(encoded)-1]},
+		{name: "trailing bytes", data: append(append([]byte(nil), encoded...), 0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var rd Round1Data
+			if err := rd.UnmarshalBinary(tt.data); !errors.Is(err, ErrInvalidEncoding) {
+				t.Errorf("expected ErrInvalidEncoding, got %v", err)
+			}
+		})
+	}
+}
+
+func TestRound1DataModulusMismatch(t *testing.T) {
+	shares, groupKey, err := GenerateKeys(2, 3, nil)
+	if err != nil {
+		t.Fatalf("GenerateKeys failed: %v", err)
+	}
+	prfKey := []byte("test-prf-key-32-bytes-long!!!!!!")
+	encoded, err := NewSigner(shares[0]).Round1(1, prfKey, []int{0, 1}).MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary failed: %v", err)
+	}
+
+	var rd Round1Data
+	if err := rd.UnmarshalBinaryFor(groupKey.Params.R, encoded); err != nil {
+		t.Fatalf("UnmarshalBinaryFor the signing ring failed: %v", err)
+	}
+
+	// Header is version (1 byte) || N (u32) || Q (u64)
+	otherQ := append([]byte(nil), encoded...)
+	utils.WireByteOrder().PutUint64(otherQ[5:13], 8380417)
+	otherN := append([]byte(nil), encoded...)
+	utils.WireByteOrder().PutUint32(otherN[1:5], 512)
+	for name, data := range map[string][]byte{"Q": otherQ, "N": otherN} {
+		if err := rd.UnmarshalBinary(data); !errors.Is(err, ErrModulusMismatch) {
+			t.Errorf("different %s: expected ErrModulusMismatch, got %v", name, err)
+		}
+	}
+
+	smallRing, err := ring.NewRing(256, []uint64{8380417})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := rd.UnmarshalBinaryFor(smallRing, encoded); !errors.Is(err, ErrModulusMismatch) {
+		t.Errorf("decoding for another ring: expected ErrModulusMismatch, got %v", err)
+	}
+}
+
+func TestSignatureEncodingMalformed(t *testing.T) {
+	shares, _, err := GenerateKeys(2, 3, nil)
+	if err != nil {
+		t.Fatalf("GenerateKeys failed: %v", err)
+	}
+	sig, err := signSession(newSigners(shares), []int{0, 1}, 1, "encoding")
+	if err != nil {
+		t.Fatalf("signing failed: %v", err)
+	}
+	encoded, err := sig.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary failed: %v", err)
+	}
+
+	// Header is version (1 byte) || N (u32) || Q (u64) || Z length (u32) || Delta length (u32)
+	longZ := append([]byte(nil), encoded...)
+	utils.WireByteOrder().PutUint32(longZ[13:17], 1<<30)
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{name: "empty", data: nil},
+		{name: "unknown version", data: append([]byte{signatureVersion + 1}, encoded[1:]...)},
+		{name: "Z length", data: longZ},
+		{name: "truncated", data: encoded[:len(encoded)-1]},
+		{name: "trailing bytes", data: append(append([]byte(nil), encoded...), 0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var decoded Signature
+			if err := decoded.UnmarshalBinary(tt.data); !errors.Is(err, ErrInvalidEncoding) {
+				t.Errorf("expected ErrInvalidEncoding, got %v", err)
+			}
+		})
+	}
+
+	otherQ := append([]byte(nil), encoded...)
+	utils.WireByteOrder().PutUint64(otherQ[5:13], 8380417)
+	var decoded Signature
+	if err := decoded.UnmarshalBinary(otherQ); !errors.Is(err, ErrModulusMismatch) {
+		t.Errorf("different Q: expected ErrModulusMismatch, got %v", err)
+	}
+}
+
+func TestGroupKeyEncoding(t *testing.T) {
+	shares, groupKey, err := GenerateKeys(2, 3, nil)
+	if err != nil {
+		t.Fatalf("GenerateKeys failed: %v", err)
+	}
+	message := "group key encoding"
+	sig, err := signSession(newSigners(shares), []int{1, 2}, 1, message)
+	if err != nil {
+		t.Fatalf("signing failed: %v", err)
+	}
+
+	encoded, err := groupKey.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes failed: %v", err)
+	}
+	again, err := shares[0].GroupKey.Bytes()
+	if err != nil || !bytes.Equal(encoded, again) {
+		t.Fatal("Bytes is not deterministic")
+	}
+	t.Logf("Group key: %d bytes", len(encoded))
+
+	parsed, err := ParseGroupKey(encoded)
+	if err != nil {
+		t.Fatalf("ParseGroupKey failed: %v", err)
+	}
+	if VerificationKeyDigest(parsed) != VerificationKeyDigest(groupKey) {
+		t.Error("parsed group key has a different digest")
+	}
+	if !Verify(parsed, message, sig) {
+		t.Error("signature does not verify under the parsed group key")
+	}
+
+	for name, gk := range map[string]*GroupKey{
+		"nil":       nil,
+		"empty":     {},
+		"no BTilde": {A: groupKey.A, Params: groupKey.Params},
+	} {
+		if _, err := gk.Bytes(); !errors.Is(err, ErrIncompleteGroupKey) {
+			t.Errorf("%s: expected ErrIncompleteGroupKey, got %v", name, err)
+		}
+	}
+
+	otherQ := append([]byte(nil), encoded...)
+	utils.WireByteOrder().PutUint64(otherQ[5:13], 8380417)
+	if _, err := ParseGroupKey(otherQ); !errors.Is(err, ErrModulusMismatch) {
+		t.Errorf("different Q: expected ErrModulusMismatch, got %v", err)
+	}
+	for name, data := range map[string][]byte{
+		"empty":          nil,
+		"truncated":      encoded[:len(encoded)-1],
+		"trailing bytes": append(append([]byte(nil), encoded...), 0),
+	} {
+		if _, err := ParseGroupKey(data); !errors.Is(err, ErrInvalidEncoding) {
+			t.Errorf("%s: expected ErrInvalidEncoding, got %v", name, err)
+		}
+	}
+}
+
+func TestEncodingByteOrder(t *testing.T) {
+	shares, groupKey, err := GenerateKeys(2, 3, nil)
+	if err != nil {
+		t.Fatalf("GenerateKeys failed: %v", err)
+	}
+	message := "byte order"
+	sig, err := signSession(newSigners(shares), []int{0, 1}, 1, message)
+	if err != nil {
+		t.Fatalf("signing failed: %v", err)
+	}
+	defaultSig, err := sig.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defaultKey, err := groupKey.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		order binary.ByteOrder
+	}{
+		{name: "little endian", order: binary.LittleEndian},
+		{name: "big endian", order: binary.BigEndian},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encodedSig, err := sig.MarshalBinaryWithOrder(tt.order)
+			if err != nil {
+				t.Fatalf("MarshalBinaryWithOrder failed: %v", err)
+			}
+			encodedKey, err := groupKey.BytesWithOrder(tt.order)
+			if err != nil {
+				t.Fatalf("BytesWithOrder failed: %v", err)
+			}
+
+			isDefault := tt.order == utils.CoefficientByteOrder()
+			if bytes.Equal(encodedSig, defaultSig) != isDefault || bytes.Equal(encodedKey, defaultKey) != isDefault {
+				t.Errorf("encoding matches the default: got %v, want %v", !isDefault, isDefault)
+			}
+			if !bytes.Equal(encodedSig[:signatureHeaderSize], defaultSig[:signatureHeaderSize]) {
+				t.Error("signature header depends on the byte order")
+			}
+			coeff := make([]byte, 8)
+			tt.order.PutUint64(coeff, sig.Z[0].Coeffs[0][1])
+			if !bytes.Contains(encodedSig, coeff) {
+				t.Errorf("encoding does not contain coefficient bytes %x", coeff)
+			}
+
+			var decoded Signature
+			if err := decoded.UnmarshalBinaryWithOrder(encodedSig, tt.order); err != nil {
+				t.Fatalf("UnmarshalBinaryWithOrder failed: %v", err)
+			}
+			reencoded, err := decoded.MarshalBinary()
+			if err != nil || !bytes.Equal(reencoded, defaultSig) {
+				t.Error("signature does not round-trip")
+			}
+			parsed, err := ParseGroupKeyWithOrder(encodedKey, tt.order)
+			if err != nil {
+				t.Fatalf("ParseGroupKeyWithOrder failed: %v", err)
+			}
+			if !Verify(parsed, message, &decoded) {
+				t.Error("decoded signature does not verify under the decoded group key")
+			}
+
+			if err := new(Signature).UnmarshalBinaryWithOrder(encodedSig[:len(encodedSig)-1], tt.order); !errors.Is(err, ErrInvalidEncoding) {
+				t.Errorf("truncated: expected ErrInvalidEncoding, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+// Copyright (C) 2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package threshold
+
+import (
+	"github.com/luxfi/ringtail/utils"
+)
+
+// expiryTag prefixes messages signed with an expiry. Messages beginning with
+// it are reserved: an application that signs with expiry must not also sign
+// plain messages that start with this tag.
+const expiryTag = "RingtailExpiryV1\x00"
+
+// expiringMessage binds validUntil into the signed transcript ahead of message.
+func expiringMessage(message string, validUntil uint64) string {
+	height := make([]byte, 8)
+	utils.TranscriptByteOrder().PutUint64(height, validUntil)
+	return expiryTag + string(height) + message
+}
+
+// Round2WithExpiry is Round2 for a signature that is valid only up to and
+// including height validUntil. Every signer must use the same validUntil.
+func (s *Signer) Round2WithExpiry(sessionID int, message string, validUntil uint64, prfKey []byte, signers []int, round1Data map[int]*Round1Data) (*Round2Data, error) {
+	return s.Round2(sessionID, expiringMessage(message, validUntil), prfKey, signers, round1Data)
+}
+
+// VerifyWithExpiry verifies a signature produced with Round2WithExpiry. It
+// rejects the signature once currentHeight exceeds validUntil, and also when
+// validUntil is not the value the signers bound.
+func VerifyWithExpiry(groupKey *GroupKey, message string, validUntil, currentHeight uint64, sig *Signature) bool {
+	if currentHeight > validUntil {
+		return false
+	}
+	return Verify(groupKey, expiringMessage(message, validUntil), sig)
+}
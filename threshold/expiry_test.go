@@ -0,0 +1,63 @@
+// Copyright (C) 2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package threshold
+
+import (
+	"testing"
+)
+
+func TestVerifyWithExpiry(t *testing.T) {
+	shares, groupKey, err := GenerateKeys(2, 3, nil)
+	if err != nil {
+		t.Fatalf("GenerateKeys failed: %v", err)
+	}
+	signers := newSigners(shares)
+	prfKey := []byte("test-prf-key-32-bytes-long!!!!!!")
+	signerIDs := []int{0, 1, 2}
+	sessionID := 1
+	message := "expiring message"
+	const validUntil = 100
+
+	round1Data := make(map[int]*Round1Data)
+	for _, id := range signerIDs {
+		data := signers[id].Round1(sessionID, prfKey, signerIDs)
+		round1Data[data.PartyID] = data
+	}
+	round2Data := make(map[int]*Round2Data)
+	for _, id := range signerIDs {
+		data, err := signers[id].Round2WithExpiry(sessionID, message, validUntil, prfKey, signerIDs, round1Data)
+		if err != nil {
+			t.Fatalf("Round2WithExpiry failed for party %d: %v", id, err)
+		}
+		round2Data[data.PartyID] = data
+	}
+	sig, err := signers[0].Finalize(round2Data)
+	if err != nil {
+		t.Fatalf("Finalize failed: %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		validUntil    uint64
+		currentHeight uint64
+		want          bool
+	}{
+		{name: "before expiry", validUntil: validUntil, currentHeight: 50, want: true},
+		{name: "at expiry", validUntil: validUntil, currentHeight: validUntil, want: true},
+		{name: "after expiry", validUntil: validUntil, currentHeight: validUntil + 1, want: false},
+		{name: "extended expiry", validUntil: 2 * validUntil, currentHeight: validUntil + 1, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := VerifyWithExpiry(groupKey, message, tt.validUntil, tt.currentHeight, sig); got != tt.want {
+				t.Errorf("VerifyWithExpiry() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if Verify(groupKey, message, sig) {
+		t.Error("expiry-bound signature verified as a plain signature")
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright (C) 2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package threshold
+
+import (
+	"sync"
+	"testing"
+)
+
+type logEvent struct {
+	level string
+	msg   string
+}
+
+// recordingLogger captures events for assertions.
+type recordingLogger struct {
+	mu     sync.Mutex
+	events []logEvent
+}
+
+func (l *recordingLogger) record(level, msg string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.events = append(l.events, logEvent{level, msg})
+}
+
+func (l *recordingLogger) Debug(msg string, _ ...any) { l.record("debug", msg) }
+func (l *recordingLogger) Info(msg string, _ ...any)  { l.record("info", msg) }
+func (l *recordingLogger) Warn(msg string, _ ...any)  { l.record("warn", msg) }
+func (l *recordingLogger) Error(msg string, _ ...any) { l.record("error", msg) }
+
+func (l *recordingLogger) has(level, msg string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, e := range l.events {
+		if e.level == level && e.msg == msg {
+			return true
+		}
+	}
+	return false
+}
+
+func TestSignerLogger(t *testing.T) {
+	shares, groupKey, err := GenerateKeys(2, 3, nil)
+	if err != nil {
+		t.Fatalf("GenerateKeys failed: %v", err)
+	}
+
+	signers := make([]*Signer, len(shares))
+	for i, share := range shares {
+		signers[i] = NewSigner(share)
+	}
+	logger := &recordingLogger{}
+	signers[0].SetLogger(logger)
+
+	sessionID := 1
+	prfKey := []byte("test-prf-key-32-bytes-long!!!!!!")
+	signerIDs := []int{0, 1, 2}
+	message := "logged message"
+
+	round1Data := make(map[int]*Round1Data)
+	for _, signer := range signers {
+		data := signer.Round1(sessionID, prfKey, signerIDs)
+		round1Data[data.PartyID] = data
+	}
+	round2Data := make(map[int]*Round2Data)
+	for _, signer := range signers {
+		data, err := signer.Round2(sessionID, message, prfKey, signerIDs, round1Data)
+		if err != nil {
+			t.Fatalf("Party %d Round2 failed: %v", signer.share.Index, err)
+		}
+		round2Data[data.PartyID] = data
+	}
+	sig, err := signers[0].Finalize(round2Data)
+	if err != nil {
+		t.Fatalf("Finalize failed: %v", err)
+	}
+	if !Verify(groupKey, message, sig) {
+		t.Fatal("signature verification failed")
+	}
+
+	for _, want := range []logEvent{
+		{"debug", "round 1 complete"},
+		{"debug", "round 2 complete"},
+		{"info", "signature finalized"},
+	} {
+		if !logger.has(want.level, want.msg) {
+			t.Errorf("logger did not receive %s event %q", want.level, want.msg)
+		}
+	}
+
+	// A corrupted MAC must surface as a warning.
+	sessionID++
+	for _, signer := range signers {
+		data := signer.Round1(sessionID, prfKey, signerIDs)
+		round1Data[data.PartyID] = data
+	}
+	round1Data[1].MACs[0] = make([]byte, len(round1Data[1].MACs[0]))
+	if _, err := signers[0].Round2(sessionID, message, prfKey, signerIDs, round1Data); err == nil {
+		t.Fatal("Round2 accepted a corrupted MAC")
+	}
+	if !logger.has("warn", "round 2 MAC verification failed") {
+		t.Error("logger did not receive the MAC failure warning")
+	}
+
+	// Signers without an injected logger must not panic.
+	signers[1].SetLogger(nil)
+	signers[1].Round1(sessionID+1, prfKey, signerIDs)
+}
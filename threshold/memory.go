@@ -0,0 +1,68 @@
+// Copyright (C) 2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package threshold
+
+import (
+	"github.com/luxfi/lattice/v7/ring"
+	"github.com/luxfi/lattice/v7/utils/structs"
+)
+
+// MemorySize returns the approximate number of bytes of polynomial data the
+// group key holds: A, BTilde, the slot keys and the unrounded public key.
+// Each coefficient takes 8 bytes, so A alone is 8·N·M·cols bytes.
+func (gk *GroupKey) MemorySize() int {
+	size := matrixBytes(gk.A) + vectorBytes(gk.BTilde) + vectorBytes(gk.b)
+	for _, slotKey := range gk.SlotKeys {
+		size += vectorBytes(slotKey)
+	}
+	return size
+}
+
+// MemorySize returns the approximate number of bytes of polynomial data the
+// share holds: its slot shares and Lagrange coefficient. SkShare is counted
+// once when it aliases the first slot share, as it does after keygen. The
+// group key is shared by all shares and is not counted.
+func (ks *KeyShare) MemorySize() int {
+	size := polyBytes(ks.Lambda)
+	for _, slotShare := range ks.SlotShares {
+		size += vectorBytes(slotShare)
+	}
+	if len(ks.SlotShares) == 0 || !sameVector(ks.SkShare, ks.SlotShares[0]) {
+		size += vectorBytes(ks.SkShare)
+	}
+	return size
+}
+
+func polyBytes(p ring.Poly) int {
+	size := 0
+	for _, level := range p.Coeffs {
+		size += 8 * len(level)
+	}
+	return size
+}
+
+func vectorBytes(v structs.Vector[ring.Poly]) int {
+	size := 0
+	for _, p := range v {
+		size += polyBytes(p)
+	}
+	return size
+}
+
+func matrixBytes(M structs.Matrix[ring.Poly]) int {
+	size := 0
+	for _, row := range M {
+		size += vectorBytes(row)
+	}
+	return size
+}
+
+// sameVector reports whether a and b share their coefficient storage.
+func sameVector(a, b structs.Vector[ring.Poly]) bool {
+	if len(a) == 0 || len(a) != len(b) || len(a[0].Coeffs) == 0 || len(b[0].Coeffs) == 0 ||
+		len(a[0].Coeffs[0]) == 0 || len(b[0].Coeffs[0]) == 0 {
+		return false
+	}
+	return &a[0].Coeffs[0][0] == &b[0].Coeffs[0][0]
+}
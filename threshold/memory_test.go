@@ -0,0 +1,67 @@
+// Copyright (C) 2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package threshold
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/luxfi/ringtail/sign"
+	"github.com/luxfi/ringtail/utils"
+
+	"github.com/luxfi/lattice/v7/ring"
+	"github.com/luxfi/lattice/v7/utils/structs"
+)
+
+func TestMemorySize(t *testing.T) {
+	tests := []struct {
+		degree     int
+		rows, cols int
+	}{
+		{degree: 256, rows: 8, cols: 7},
+		{degree: 256, rows: 8, cols: 14},
+		{degree: 256, rows: 16, cols: 7},
+		{degree: 512, rows: 8, cols: 7},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("N=%d %dx%d", tt.degree, tt.rows, tt.cols), func(t *testing.T) {
+			r, err := ring.NewRing(tt.degree, []uint64{8380417})
+			if err != nil {
+				t.Fatal(err)
+			}
+			gk := &GroupKey{
+				A:      utils.InitializeMatrix(r, tt.rows, tt.cols),
+				BTilde: utils.InitializeVector(r, tt.rows),
+			}
+			if got, want := gk.MemorySize(), 8*tt.degree*(tt.rows*tt.cols+tt.rows); got != want {
+				t.Errorf("GroupKey.MemorySize() = %d, want %d", got, want)
+			}
+
+			share := utils.InitializeVector(r, tt.cols)
+			ks := &KeyShare{
+				SkShare:    share,
+				Lambda:     r.NewPoly(),
+				SlotShares: []structs.Vector[ring.Poly]{share, utils.InitializeVector(r, tt.cols)},
+			}
+			if got, want := ks.MemorySize(), 8*tt.degree*(2*tt.cols+1); got != want {
+				t.Errorf("KeyShare.MemorySize() = %d, want %d", got, want)
+			}
+		})
+	}
+
+	shares, groupKey, err := GenerateKeys(2, 3, nil)
+	if err != nil {
+		t.Fatalf("GenerateKeys failed: %v", err)
+	}
+	n := 1 << sign.LogN
+	// A, BTilde, b and one slot key per party.
+	if got, want := groupKey.MemorySize(), 8*n*(sign.M*sign.N+2*sign.M+3*sign.M); got != want {
+		t.Errorf("GroupKey.MemorySize() = %d, want %d", got, want)
+	}
+	if got, want := shares[0].MemorySize(), 8*n*(sign.N+1); got != want {
+		t.Errorf("KeyShare.MemorySize() = %d, want %d", got, want)
+	}
+	t.Logf("group key: %d bytes, key share: %d bytes", groupKey.MemorySize(), shares[0].MemorySize())
+}
@@ -0,0 +1,204 @@
+// Copyright (C) 2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package threshold
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/luxfi/ringtail/networking"
+)
+
+// netParty is one endpoint of the in-memory network, with a reader and writer
+// kept per peer so buffered bytes are not lost between rounds.
+type netParty struct {
+	comm    *networking.P2PComm
+	readers map[int]*bufio.Reader
+	writers map[int]*bufio.Writer
+}
+
+// connectParties joins n parties pairwise with net.Pipe and runs the identity
+// exchange on every connection.
+func connectParties(t *testing.T, n int) []*netParty {
+	t.Helper()
+	parties := make([]*netParty, n)
+	for i := range parties {
+		parties[i] = &netParty{comm: &networking.P2PComm{Rank: i, Socks: make(map[int]*net.Conn)}}
+	}
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			a, b := net.Pipe()
+			parties[i].comm.SetSock(j, &a)
+			parties[j].comm.SetSock(i, &b)
+		}
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, n*n)
+	for i, p := range parties {
+		for j := 0; j < n; j++ {
+			if j == i {
+				continue
+			}
+			wg.Add(1)
+			go func(p *netParty, peer int) {
+				defer wg.Done()
+				errs <- p.comm.ExchangeIdentity(peer)
+			}(p, j)
+		}
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("identity exchange failed: %v", err)
+		}
+	}
+
+	for i, p := range parties {
+		p.readers = make(map[int]*bufio.Reader)
+		p.writers = make(map[int]*bufio.Writer)
+		for j := 0; j < n; j++ {
+			if j != i {
+				p.readers[j] = p.comm.NewReader(j)
+				p.writers[j] = p.comm.NewWriter(j)
+			}
+		}
+	}
+	return parties
+}
+
+// broadcast sends msg to every peer while receiving theirs. Sends run in
+// their own goroutines since net.Pipe blocks until the other end reads; on a
+// receive error they are left to fail when the connections close.
+func (p *netParty) broadcast(peers []int, msg []byte) (map[int][]byte, error) {
+	sent := make(chan error, len(peers))
+	for _, peer := range peers {
+		go func(peer int) {
+			_, err := p.comm.SendBytes(p.writers[peer], peer, msg)
+			sent <- err
+		}(peer)
+	}
+	received := make(map[int][]byte, len(peers))
+	for _, peer := range peers {
+		data, _, err := p.comm.Recv(p.readers[peer], peer)
+		if err != nil {
+			return nil, fmt.Errorf("receive from %d: %w", peer, err)
+		}
+		received[peer] = data
+	}
+	for range peers {
+		if err := <-sent; err != nil {
+			return nil, err
+		}
+	}
+	return received, nil
+}
+
+// runSession runs both rounds for signer over the network, exchanging
+// Round1Data in its binary encoding and Round2Data in its protobuf encoding.
+func (p *netParty) runSession(signer *Signer, sessionID int, message string, prfKey []byte, signerIDs []int) (*Signature, error) {
+	var peers []int
+	for _, id := range signerIDs {
+		if id != p.comm.Rank {
+			peers = append(peers, id)
+		}
+	}
+
+	own1 := signer.Round1(sessionID, prfKey, signerIDs)
+	encoded, err := own1.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	received, err := p.broadcast(peers, encoded)
+	if err != nil {
+		return nil, err
+	}
+	round1Data := map[int]*Round1Data{p.comm.Rank: own1}
+	for peer, data := range received {
+		var rd Round1Data
+		if err := rd.UnmarshalBinary(data); err != nil {
+			return nil, fmt.Errorf("round 1 data from %d: %w", peer, err)
+		}
+		round1Data[peer] = &rd
+	}
+
+	own2, err := signer.Round2(sessionID, message, prfKey, signerIDs, round1Data)
+	if err != nil {
+		return nil, err
+	}
+	if encoded, err = own2.MarshalProto(); err != nil {
+		return nil, err
+	}
+	if received, err = p.broadcast(peers, encoded); err != nil {
+		return nil, err
+	}
+	round2Data := map[int]*Round2Data{p.comm.Rank: own2}
+	for peer, data := range received {
+		var rd Round2Data
+		if err := rd.UnmarshalProto(data); err != nil {
+			return nil, fmt.Errorf("round 2 data from %d: %w", peer, err)
+		}
+		round2Data[peer] = &rd
+	}
+	return signer.Finalize(round2Data)
+}
+
+func TestNetworkedSigningFlow(t *testing.T) {
+	shares, groupKey, err := GenerateKeys(2, 3, nil)
+	if err != nil {
+		t.Fatalf("GenerateKeys failed: %v", err)
+	}
+	parties := connectParties(t, 3)
+	defer func() {
+		for _, p := range parties {
+			p.comm.Close()
+		}
+	}()
+
+	prfKey := []byte("test-prf-key-32-bytes-long!!!!!!")
+	signerIDs := []int{0, 2}
+	message := "networked signing"
+
+	sigs := make([]*Signature, len(parties))
+	errs := make([]error, len(parties))
+	var wg sync.WaitGroup
+	for _, id := range signerIDs {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			sigs[id], errs[id] = parties[id].runSession(NewSigner(shares[id]), 1, message, prfKey, signerIDs)
+		}(id)
+	}
+	wg.Wait()
+	for _, id := range signerIDs {
+		if errs[id] != nil {
+			t.Fatalf("party %d failed to sign: %v", id, errs[id])
+		}
+	}
+
+	// Party 0 hands the signature to party 1, which did not sign.
+	encoded, err := sigs[0].MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary failed: %v", err)
+	}
+	go parties[0].comm.SendBytes(parties[0].writers[1], 1, encoded)
+	data, _, err := parties[1].comm.Recv(parties[1].readers[0], 0)
+	if err != nil {
+		t.Fatalf("receiving the signature failed: %v", err)
+	}
+	var sig Signature
+	if err := sig.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary failed: %v", err)
+	}
+	if !Verify(shares[1].GroupKey, message, &sig) {
+		t.Error("signature received over the network does not verify")
+	}
+	if !Verify(groupKey, message, sigs[2]) {
+		t.Error("party 2's signature does not verify")
+	}
+}
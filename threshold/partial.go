@@ -0,0 +1,267 @@
+// Copyright (C) 2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package threshold
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"slices"
+
+	"github.com/luxfi/ringtail/primitives"
+	"github.com/luxfi/ringtail/sign"
+	"github.com/luxfi/ringtail/utils"
+
+	"github.com/luxfi/lattice/v7/ring"
+	"github.com/luxfi/lattice/v7/utils/sampling"
+	"github.com/luxfi/lattice/v7/utils/structs"
+)
+
+// ErrPartialVerifyUnsupported is returned by PartialVerify when the honest
+// residual of a party, scaled by its Lagrange denominators, may reach q/4, so
+// a share that fails the check could still be honest.
+var ErrPartialVerifyUnsupported = errors.New("partial verification unsupported for this signer set")
+
+// PartialSignature is one party's z share for a session. A coordinator checks
+// each one with PartialVerify before aggregation, so an invalid contribution
+// is attributed to the party that sent it.
+type PartialSignature struct {
+	PartyID   int
+	SessionID int
+	Z         structs.Vector[ring.Poly]
+}
+
+// Partial wraps a Round 2 share as the PartialSignature for sessionID.
+func (d *Round2Data) Partial(sessionID int) *PartialSignature {
+	return &PartialSignature{
+		PartyID:   d.PartyID,
+		SessionID: sessionID,
+		Z:         d.Z,
+	}
+}
+
+// partialSession is the Round 2 context PartialVerify recomputes shares from.
+type partialSession struct {
+	sessionID int
+	message   string
+	prfKey    []byte
+	signers   []int
+	hash      []byte
+	u         structs.Vector[ring.Poly]
+	c         ring.Poly
+}
+
+// recordPartialSession keeps the context of the Round 2 just completed. It must
+// run after SignRound2, which sets the challenge.
+func (s *Signer) recordPartialSession(sessionID int, message string, prfKey []byte, signers []int, hash []byte) {
+	r := s.params.R
+	u := structs.Vector[ring.Poly]{montgomeryOne(r)}
+	u = append(u, primitives.GaussianHash(r, hash, message, sign.SigmaU, sign.BoundU, sign.Dbar)...)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.partial = &partialSession{
+		sessionID: sessionID,
+		message:   message,
+		prfKey:    prfKey,
+		signers:   signers,
+		hash:      hash,
+		u:         u,
+		c:         s.party.C,
+	}
+}
+
+// PartialVerify reports whether partial is a valid z share from partyID for
+// the last session this signer completed Round 2 in, given the Round 1
+// commitments of that session. A false result with a nil error means the share
+// is invalid. Every honest share satisfies
+//
+//	A·z_j - D_j·u - A·m_j - c·Σ λ_k·SlotKeys[k] = -E_j·u - c·Σ λ_k·e_k
+//
+// over the party's slots k, where m_j is its net pairwise mask. The right-hand
+// side is small once scaled by the Lagrange denominators Δ, while a tampered
+// share leaves a uniform residual. Δ grows factorially with the number of
+// signing slots, so once the scaled residual may reach q/4, typically beyond
+// about five slots, PartialVerify fails with ErrPartialVerifyUnsupported
+// instead. It fails with ErrInsufficientData without a completed Round 2 or
+// Round 1 data from partyID.
+func (s *Signer) PartialVerify(partyID int, partial *PartialSignature, round1 map[int]*Round1Data) (bool, error) {
+	s.mu.Lock()
+	session := s.partial
+	s.mu.Unlock()
+	if session == nil {
+		return false, fmt.Errorf("%w: no completed Round 2", ErrInsufficientData)
+	}
+	if partial == nil || partial.PartyID != partyID || partial.SessionID != session.sessionID {
+		return false, nil
+	}
+	if !slices.Contains(session.signers, partyID) || len(partial.Z) != sign.N {
+		return false, nil
+	}
+	data, ok := round1[partyID]
+	if !ok || data == nil {
+		return false, fmt.Errorf("%w: no Round 1 data from party %d", ErrInsufficientData, partyID)
+	}
+	if data.PartyID != partyID || len(data.D) != sign.M {
+		return false, nil
+	}
+	for _, row := range data.D {
+		if len(row) != sign.Dbar+1 {
+			return false, nil
+		}
+	}
+
+	gk := s.share.GroupKey
+	r := s.params.R
+	lambdas, err := s.slotLambdas(session.signers)
+	if err != nil {
+		return false, err
+	}
+	slots, _ := gk.signerSlots(session.signers)
+	start, end := gk.slotRange(partyID)
+	scale := lagrangeDenominator(slots, start, end)
+	if bound := partialResidualBound(slots, start, end, scale); bound.Cmp(new(big.Int).Rsh(r.Modulus(), 2)) >= 0 {
+		return false, fmt.Errorf("%w: party %d over %d slots", ErrPartialVerifyUnsupported, partyID, len(slots))
+	}
+
+	residual := utils.InitializeVector(r, sign.M)
+	utils.MatrixVectorMul(r, gk.A, partial.Z, residual)
+
+	// MatrixVectorMul accumulates into its result, so each product gets a fresh vector.
+	Du := utils.InitializeVector(r, sign.M)
+	utils.MatrixVectorMul(r, data.D, session.u, Du)
+	utils.VectorSub(r, residual, Du, residual)
+
+	Am := utils.InitializeVector(r, sign.M)
+	utils.MatrixVectorMul(r, gk.A, s.netMask(session, partyID), Am)
+	utils.VectorSub(r, residual, Am, residual)
+
+	key := utils.InitializeVector(r, sign.M)
+	term := utils.InitializeVector(r, sign.M)
+	for k := start; k < end; k++ {
+		utils.VectorPolyMul(r, gk.SlotKeys[k], lambdas[k], term)
+		utils.VectorAdd(r, key, term, key)
+	}
+	utils.VectorPolyMul(r, key, session.c, key)
+	utils.VectorSub(r, residual, key, residual)
+
+	return residualIsSmall(r, residual, scale), nil
+}
+
+// netMask returns maskPrime_j - mask_j, the pairwise PRF masks party j folded
+// into its z share in SignRound2.
+func (s *Signer) netMask(session *partialSession, partyID int) structs.Vector[ring.Poly] {
+	r := s.params.R
+	seeds := s.share.Seeds
+	mask := utils.InitializeVector(r, sign.N)
+	mask_j := utils.InitializeVector(r, sign.N)
+	for _, j := range session.signers {
+		primitives.PRFInto(r, seeds[j][partyID], session.prfKey, session.message, session.hash, mask_j)
+		utils.VectorAdd(r, mask, mask_j, mask)
+		primitives.PRFInto(r, seeds[partyID][j], session.prfKey, session.message, session.hash, mask_j)
+		utils.VectorSub(r, mask, mask_j, mask)
+	}
+	return mask
+}
+
+// lagrangeDenominator returns the least common multiple of the Lagrange
+// denominators Π_{m≠k} (x_m - x_k) of slots k in [start, end), with x = slot+1
+// as in primitives.ComputeLagrangeCoefficients.
+func lagrangeDenominator(slots []int, start, end int) *big.Int {
+	lcm := big.NewInt(1)
+	gcd := new(big.Int)
+	for k := start; k < end; k++ {
+		den := big.NewInt(1)
+		for _, m := range slots {
+			if m != k {
+				den.Mul(den, big.NewInt(int64(m-k)))
+			}
+		}
+		den.Abs(den)
+		gcd.GCD(nil, nil, lcm, den)
+		lcm.Mul(lcm, den.Div(den, gcd))
+	}
+	return lcm
+}
+
+// partialNoiseBound bounds the coefficients of E_j·u in an honest residual. The
+// e* column of E_j is cut off at BoundStar, and E_i·h_u sums Dbar·N products
+// of Gaussians of widths SigmaE and SigmaU, which stays below ten standard
+// deviations with overwhelming probability.
+func partialNoiseBound() *big.Int {
+	products := float64(sign.Dbar * (1 << sign.LogN))
+	bound := sign.BoundStar + 10*math.Sqrt(products)*sign.SigmaE*sign.SigmaU
+	return new(big.Int).SetUint64(uint64(math.Ceil(bound)))
+}
+
+// partialResidualBound bounds the coefficients of scale·v for the honest
+// residual v of the party holding slots [start, end) of slots: scale·E_j·u
+// plus c·Σ scale·λ_k·e_k, where each scale·λ_k is the integer
+// scale·Π x_m / Π (x_m - x_k) and c·e_k is at most Kappa·BoundE.
+func partialResidualBound(slots []int, start, end int, scale *big.Int) *big.Int {
+	bound := new(big.Int).Mul(scale, partialNoiseBound())
+	lambdaSum := new(big.Int)
+	for k := start; k < end; k++ {
+		num, den := big.NewInt(1), big.NewInt(1)
+		for _, m := range slots {
+			if m != k {
+				num.Mul(num, big.NewInt(int64(m+1)))
+				den.Mul(den, big.NewInt(int64(m-k)))
+			}
+		}
+		num.Mul(num, scale).Quo(num, den.Abs(den))
+		lambdaSum.Add(lambdaSum, num)
+	}
+	lambdaSum.Mul(lambdaSum, big.NewInt(int64(sign.Kappa)*int64(math.Ceil(sign.BoundE))))
+	return bound.Add(bound, lambdaSum)
+}
+
+// residualIsSmall reports whether every centered coefficient of scale·v, for v
+// in NTT and Montgomery form, lies below q/4. v is converted in place.
+func residualIsSmall(r *ring.Ring, v structs.Vector[ring.Poly], scale *big.Int) bool {
+	q := r.Modulus()
+	half := new(big.Int).Rsh(q, 1)
+	bound := new(big.Int).Rsh(q, 2)
+
+	utils.ConvertVectorFromNTT(r, v)
+	coeffs := make([]*big.Int, r.N())
+	for _, p := range v {
+		r.PolyToBigint(p, 1, coeffs)
+		for _, coeff := range coeffs {
+			coeff.Mul(coeff, scale).Mod(coeff, q)
+			if coeff.Cmp(half) > 0 {
+				coeff.Sub(q, coeff)
+			}
+			if coeff.Cmp(bound) >= 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// slotPublicKeys returns A·s_k + e_k for every slot share s_k, with noise
+// sampled at SigmaE from a key read off the dealer's Shamir stream.
+func slotPublicKeys(r *ring.Ring, A structs.Matrix[ring.Poly], slotShares map[int]structs.Vector[ring.Poly], stream io.Reader) ([]structs.Vector[ring.Poly], error) {
+	noiseKey := make([]byte, sign.KeySize)
+	if _, err := io.ReadFull(stream, noiseKey); err != nil {
+		return nil, err
+	}
+	prng, err := sampling.NewKeyedPRNG(noiseKey)
+	if err != nil {
+		return nil, err
+	}
+	gaussianSampler := ring.NewGaussianSampler(prng, r, ring.DiscreteGaussian{Sigma: sign.SigmaE, Bound: sign.BoundE}, false)
+
+	keys := make([]structs.Vector[ring.Poly], len(slotShares))
+	for k := range keys {
+		keys[k] = utils.SamplePolyVector(r, sign.M, gaussianSampler, true, true)
+		As := utils.InitializeVector(r, sign.M)
+		utils.MatrixVectorMul(r, A, slotShares[k], As)
+		utils.VectorAdd(r, keys[k], As, keys[k])
+	}
+	return keys, nil
+}
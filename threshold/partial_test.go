@@ -0,0 +1,103 @@
+// Copyright (C) 2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package threshold
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/luxfi/lattice/v7/ring"
+	"github.com/luxfi/lattice/v7/utils/structs"
+)
+
+func TestPartialVerify(t *testing.T) {
+	tests := []struct {
+		name      string
+		weights   []int
+		threshold int
+		signerIDs []int
+		corrupt   int
+	}{
+		{name: "2 of 3", weights: []int{1, 1, 1}, threshold: 2, signerIDs: []int{0, 1, 2}, corrupt: 2},
+		{name: "weighted", weights: []int{3, 1, 1}, threshold: 3, signerIDs: []int{0, 1}, corrupt: 0},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shares, _, err := GenerateWeightedKeys(tt.weights, tt.threshold, nil)
+			if err != nil {
+				t.Fatalf("GenerateWeightedKeys failed: %v", err)
+			}
+			signers := newSigners(shares)
+			prfKey := []byte("test-prf-key-32-bytes-long!!!!!!")
+			sessionID := i + 1
+
+			round1Data := make(map[int]*Round1Data)
+			for _, id := range tt.signerIDs {
+				round1Data[id] = signers[id].Round1(sessionID, prfKey, tt.signerIDs)
+			}
+
+			partials := make(map[int]*PartialSignature)
+			for _, id := range tt.signerIDs {
+				data, err := signers[id].Round2(sessionID, "partial", prfKey, tt.signerIDs, round1Data)
+				if err != nil {
+					t.Fatalf("Round2(%d) failed: %v", id, err)
+				}
+				partials[id] = data.Partial(sessionID)
+			}
+
+			// Corrupt one share by adding 1 to its first polynomial.
+			r := shares[0].GroupKey.Params.R
+			bad := partials[tt.corrupt]
+			z := make(structs.Vector[ring.Poly], len(bad.Z))
+			for k := range bad.Z {
+				z[k] = *bad.Z[k].CopyNew()
+			}
+			r.Add(z[0], montgomeryOne(r), z[0])
+			partials[tt.corrupt] = &PartialSignature{PartyID: bad.PartyID, SessionID: sessionID, Z: z}
+
+			coordinator := signers[tt.signerIDs[0]]
+			for _, id := range tt.signerIDs {
+				got, err := coordinator.PartialVerify(id, partials[id], round1Data)
+				if err != nil {
+					t.Fatalf("PartialVerify(%d) failed: %v", id, err)
+				}
+				if want := id != tt.corrupt; got != want {
+					t.Errorf("PartialVerify(%d) = %v, want %v", id, got, want)
+				}
+			}
+
+			if ok, err := coordinator.PartialVerify(tt.signerIDs[1], partials[tt.signerIDs[0]], round1Data); ok || err != nil {
+				t.Errorf("PartialVerify of a share under the wrong party ID = %v, %v, want false", ok, err)
+			}
+		})
+	}
+}
+
+func TestPartialVerifyUnsupported(t *testing.T) {
+	// Party 0 signs alone over six slots, so its Lagrange denominators
+	// reach 5! = 120 and the scaled residual no longer fits below q/4.
+	shares, _, err := GenerateWeightedKeys([]int{6, 1}, 6, nil)
+	if err != nil {
+		t.Fatalf("GenerateWeightedKeys failed: %v", err)
+	}
+	signer := NewSigner(shares[0])
+	prfKey := []byte("test-prf-key-32-bytes-long!!!!!!")
+	signerIDs := []int{0}
+	sessionID := 1
+
+	if _, err := signer.PartialVerify(0, &PartialSignature{PartyID: 0, SessionID: sessionID}, nil); !errors.Is(err, ErrInsufficientData) {
+		t.Errorf("before Round 2: expected ErrInsufficientData, got %v", err)
+	}
+
+	round1Data := map[int]*Round1Data{0: signer.Round1(sessionID, prfKey, signerIDs)}
+	data, err := signer.Round2(sessionID, "unsupported", prfKey, signerIDs, round1Data)
+	if err != nil {
+		t.Fatalf("Round2 failed: %v", err)
+	}
+	ok, err := signer.PartialVerify(0, data.Partial(sessionID), round1Data)
+	if !errors.Is(err, ErrPartialVerifyUnsupported) || ok {
+		t.Errorf("got %v and %v, want ErrPartialVerifyUnsupported", ok, err)
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright (C) 2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package threshold
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/luxfi/ringtail/sign"
+
+	"github.com/luxfi/lattice/v7/ring"
+	"github.com/luxfi/lattice/v7/utils/structs"
+)
+
+// verificationDataVersion is the first byte of every PrecomputeVerificationData blob.
+const verificationDataVersion = 1
+
+// PrecomputeVerificationData prepares groupKey as PrepareGroupKey does and
+// encodes the result, so a verifier that restarts often can reload it with
+// LoadVerificationData instead of preparing the key again. The encoding is
+//
+//	version (1 byte) || N (u32) || Q (u64) || QXi (u64) || QNu (u64) ||
+//	A || BTilde || b (lattice encoding) || hash prefix length (u32) || hash prefix
+//
+// with A and b in NTT form and integers in utils.WireByteOrder. It returns nil
+// for a nil or incomplete group key.
+func PrecomputeVerificationData(groupKey *GroupKey) []byte {
+	if groupKey == nil || groupKey.Params == nil || len(groupKey.A) == 0 || len(groupKey.BTilde) == 0 {
+		return nil
+	}
+	params := groupKey.Params
+	pk := PrepareGroupKey(groupKey).key
+
+	buf := new(bytes.Buffer)
+	buf.WriteByte(verificationDataVersion)
+	writeUint32(buf, uint32(params.R.N()))
+	writeUint64(buf, params.R.Modulus().Uint64())
+	writeUint64(buf, params.RXi.Modulus().Uint64())
+	writeUint64(buf, params.RNu.Modulus().Uint64())
+	for _, w := range []io.WriterTo{pk.A, pk.BTilde, pk.B} {
+		if _, err := w.WriteTo(buf); err != nil {
+			return nil
+		}
+	}
+	writeUint32(buf, uint32(len(pk.HashPrefix)))
+	buf.Write(pk.HashPrefix)
+	return buf.Bytes()
+}
+
+// LoadVerificationData decodes data from PrecomputeVerificationData into a
+// prepared group key. The stored ring must be that of a preset, with matching
+// rounding moduli, or it fails with ErrModulusMismatch. The precomputation is
+// taken as stored, so data must come from storage trusted like the group key.
+func LoadVerificationData(data []byte) (*PreparedGroupKey, error) {
+	reader := bufio.NewReader(bytes.NewReader(data))
+
+	version, err := reader.ReadByte()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidEncoding, err)
+	}
+	if version != verificationDataVersion {
+		return nil, fmt.Errorf("%w: unsupported verification data version %d", ErrInvalidEncoding, version)
+	}
+	n, err := readUint32(reader)
+	if err != nil {
+		return nil, err
+	}
+	var moduli [3]uint64
+	for i := range moduli {
+		if moduli[i], err = readUint64(reader); err != nil {
+			return nil, err
+		}
+	}
+	preset, err := presetForRing(n, moduli[0])
+	if err != nil {
+		return nil, err
+	}
+	if moduli[1] != preset.QXi || moduli[2] != preset.QNu {
+		return nil, fmt.Errorf("%w: rounding moduli QXi=%d QNu=%d, preset %s has %d and %d",
+			ErrModulusMismatch, moduli[1], moduli[2], preset.Name, preset.QXi, preset.QNu)
+	}
+
+	var A structs.Matrix[ring.Poly]
+	var bTilde, b structs.Vector[ring.Poly]
+	for _, r := range []io.ReaderFrom{&A, &bTilde, &b} {
+		if _, err := r.ReadFrom(reader); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidEncoding, err)
+		}
+	}
+	if len(A) != preset.M || len(bTilde) != preset.M || len(b) != preset.M {
+		return nil, fmt.Errorf("%w: verification data has %d, %d and %d rows, want %d", ErrInvalidEncoding, len(A), len(bTilde), len(b), preset.M)
+	}
+	for _, row := range A {
+		if len(row) != preset.N {
+			return nil, fmt.Errorf("%w: A row has %d columns, want %d", ErrInvalidEncoding, len(row), preset.N)
+		}
+	}
+	prefixLen, err := readUint32(reader)
+	if err != nil {
+		return nil, err
+	}
+	if int(prefixLen) > len(data) {
+		return nil, fmt.Errorf("%w: hash prefix length %d exceeds input", ErrInvalidEncoding, prefixLen)
+	}
+	prefix := make([]byte, prefixLen)
+	if _, err := io.ReadFull(reader, prefix); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidEncoding, err)
+	}
+	if _, err := reader.ReadByte(); err != io.EOF {
+		return nil, fmt.Errorf("%w: trailing bytes", ErrInvalidEncoding)
+	}
+
+	params, err := NewParamsFromPreset(preset)
+	if err != nil {
+		return nil, err
+	}
+	return &PreparedGroupKey{
+		GroupKey: &GroupKey{A: A, BTilde: bTilde, Params: params},
+		key:      &sign.PreparedKey{A: A, BTilde: bTilde, B: b, HashPrefix: prefix},
+	}, nil
+}
@@ -0,0 +1,78 @@
+// Copyright (C) 2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package threshold
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/luxfi/ringtail/utils"
+)
+
+func TestLoadVerificationData(t *testing.T) {
+	shares, groupKey, err := GenerateKeys(2, 3, nil)
+	if err != nil {
+		t.Fatalf("GenerateKeys failed: %v", err)
+	}
+	message := "precomputed"
+	sig, err := signSession(newSigners(shares), []int{0, 2}, 1, message)
+	if err != nil {
+		t.Fatalf("signing failed: %v", err)
+	}
+
+	data := PrecomputeVerificationData(groupKey)
+	if data == nil {
+		t.Fatal("PrecomputeVerificationData returned nil")
+	}
+	t.Logf("Verification data: %d bytes", len(data))
+	loaded, err := LoadVerificationData(data)
+	if err != nil {
+		t.Fatalf("LoadVerificationData failed: %v", err)
+	}
+	if VerificationKeyDigest(loaded.GroupKey) != VerificationKeyDigest(groupKey) {
+		t.Error("loaded group key has a different digest")
+	}
+
+	fresh := PrepareGroupKey(groupKey)
+	tests := []struct {
+		name    string
+		message string
+		sig     *Signature
+	}{
+		{name: "valid", message: message, sig: sig},
+		{name: "wrong message", message: "other message", sig: sig},
+		{name: "nil signature", message: message, sig: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := VerifyPrepared(fresh, tt.message, tt.sig)
+			if got := VerifyPrepared(loaded, tt.message, tt.sig); got != want {
+				t.Errorf("loaded data verifies %v, fresh key %v", got, want)
+			}
+		})
+	}
+	if !VerifyPrepared(loaded, message, sig) {
+		t.Error("valid signature rejected with loaded data")
+	}
+
+	if PrecomputeVerificationData(nil) != nil || PrecomputeVerificationData(&GroupKey{}) != nil {
+		t.Error("PrecomputeVerificationData encoded an incomplete group key")
+	}
+
+	// Header is version (1 byte) || N (u32) || Q (u64) || QXi (u64) || QNu (u64)
+	otherQXi := append([]byte(nil), data...)
+	utils.WireByteOrder().PutUint64(otherQXi[13:21], 1<<20)
+	if _, err := LoadVerificationData(otherQXi); !errors.Is(err, ErrModulusMismatch) {
+		t.Errorf("different QXi: expected ErrModulusMismatch, got %v", err)
+	}
+	for name, bad := range map[string][]byte{
+		"empty":          nil,
+		"truncated":      data[:len(data)-1],
+		"trailing bytes": append(append([]byte(nil), data...), 0),
+	} {
+		if _, err := LoadVerificationData(bad); !errors.Is(err, ErrInvalidEncoding) {
+			t.Errorf("%s: expected ErrInvalidEncoding, got %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright (C) 2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package threshold
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/luxfi/ringtail/sign"
+)
+
+var (
+	ErrInvalidPRFKey  = errors.New("invalid PRF key")
+	ErrPRFKeyMismatch = errors.New("PRF key differs from the one the session started with")
+)
+
+// RotatePRFKey makes newKey the PRF key of sessions that start after it, when
+// Round1 and Round2 are called with a nil prfKey. The PRF key derives the
+// pairwise masks of Round 2, so rotating it limits what a leaked key exposes
+// without dealing new shares. All signers must rotate between the same two
+// sessions, as every signer of a session needs the same key.
+//
+// A session keeps the key it started Round 1 with: rotating while it is in
+// flight does not change its Round 2, and Round2 rejects an explicit key that
+// differs from it with ErrPRFKeyMismatch.
+func (s *Signer) RotatePRFKey(newKey []byte) error {
+	if len(newKey) != sign.KeySize {
+		return fmt.Errorf("%w: %d bytes, want %d", ErrInvalidPRFKey, len(newKey), sign.KeySize)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.prfKey = append([]byte(nil), newKey...)
+	return nil
+}
+
+// startPRFKey records sessionID as the pending session, bound to prfKey or to
+// the current key if prfKey is nil.
+func (s *Signer) startPRFKey(sessionID int, prfKey []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if prfKey == nil {
+		prfKey = s.prfKey
+	}
+	s.pending, s.hasPending = sessionID, true
+	s.pendingPRFKey = prfKey
+}
+
+// roundPRFKey returns the key Round2 uses for sessionID: the one its Round 1
+// was bound to if the signer holds that session, else the current one. An
+// explicit prfKey must match the bound key.
+func (s *Signer) roundPRFKey(sessionID int, prfKey []byte) ([]byte, error) {
+	s.mu.Lock()
+	bound, inFlight := s.prfKey, false
+	if s.hasPending && s.pending == sessionID {
+		bound, inFlight = s.pendingPRFKey, true
+	}
+	s.mu.Unlock()
+
+	switch {
+	case prfKey == nil:
+		prfKey = bound
+	case inFlight && bound != nil && !bytes.Equal(prfKey, bound):
+		return nil, fmt.Errorf("%w: session %d", ErrPRFKeyMismatch, sessionID)
+	}
+	if len(prfKey) == 0 {
+		return nil, fmt.Errorf("%w: no key for session %d", ErrInvalidPRFKey, sessionID)
+	}
+	return prfKey, nil
+}
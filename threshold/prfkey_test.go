@@ -0,0 +1,85 @@
+// Copyright (C) 2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package threshold
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestRotatePRFKey(t *testing.T) {
+	shares, groupKey, err := GenerateKeys(2, 3, nil)
+	if err != nil {
+		t.Fatalf("GenerateKeys failed: %v", err)
+	}
+	signers := newSigners(shares)
+	signerIDs := []int{0, 1}
+	oldKey := []byte("test-prf-key-32-bytes-long!!!!!!")
+	newKey := bytes.Repeat([]byte{7}, 32)
+
+	rotate := func(key []byte) {
+		t.Helper()
+		for _, s := range signers {
+			if err := s.RotatePRFKey(key); err != nil {
+				t.Fatalf("RotatePRFKey failed: %v", err)
+			}
+		}
+	}
+	// run signs a session with nil PRF keys, calling between after Round 1.
+	run := func(sessionID int, message string, between func()) *Signature {
+		t.Helper()
+		round1Data := make(map[int]*Round1Data)
+		for _, id := range signerIDs {
+			round1Data[id] = signers[id].Round1(sessionID, nil, signerIDs)
+		}
+		between()
+		round2Data := make(map[int]*Round2Data)
+		for _, id := range signerIDs {
+			data, err := signers[id].Round2(sessionID, message, nil, signerIDs, round1Data)
+			if err != nil {
+				t.Fatalf("Round2(%d) failed: %v", id, err)
+			}
+			round2Data[id] = data
+		}
+		sig, err := signers[0].Finalize(round2Data)
+		if err != nil {
+			t.Fatalf("Finalize failed: %v", err)
+		}
+		return sig
+	}
+
+	if err := signers[0].RotatePRFKey(oldKey[:16]); !errors.Is(err, ErrInvalidPRFKey) {
+		t.Errorf("short key: expected ErrInvalidPRFKey, got %v", err)
+	}
+
+	rotate(oldKey)
+	if sig := run(1, "before", func() {}); !Verify(groupKey, "before", sig) {
+		t.Error("signature before rotation does not verify")
+	}
+
+	// Rotating mid-session leaves the session on the key it started with.
+	straddling := run(2, "in flight", func() {
+		rotate(newKey)
+		if _, err := signers[0].Round2(2, "in flight", newKey, signerIDs, nil); !errors.Is(err, ErrPRFKeyMismatch) {
+			t.Errorf("expected ErrPRFKeyMismatch, got %v", err)
+		}
+	})
+	if !Verify(groupKey, "in flight", straddling) {
+		t.Error("signature of the in-flight session does not verify")
+	}
+	if got, _ := signers[1].roundPRFKey(3, nil); !bytes.Equal(got, newKey) {
+		t.Error("sessions after the rotation do not use the new key")
+	}
+
+	if sig := run(3, "after", func() {}); !Verify(groupKey, "after", sig) {
+		t.Error("signature after rotation does not verify")
+	}
+
+	fresh := NewSigner(shares[2])
+	fresh.Round1(4, nil, []int{0, 2})
+	if _, err := fresh.Round2(4, "no key", nil, []int{0, 2}, nil); !errors.Is(err, ErrInvalidPRFKey) {
+		t.Errorf("no key: expected ErrInvalidPRFKey, got %v", err)
+	}
+}
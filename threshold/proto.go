@@ -0,0 +1,266 @@
+// Copyright (C) 2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package threshold
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sort"
+
+	"github.com/luxfi/ringtail/sign"
+	"github.com/luxfi/ringtail/utils"
+
+	"github.com/luxfi/lattice/v7/ring"
+	"github.com/luxfi/lattice/v7/utils/structs"
+)
+
+// Protobuf wire types used by the schema in ringtail.proto.
+const (
+	protoVarint  = 0
+	protoFixed64 = 1
+	protoBytes   = 2
+	protoFixed32 = 5
+)
+
+// MarshalProto encodes the Round 1 broadcast as the Round1Data message of
+// ringtail.proto, with MACs in ascending recipient order so equal data always
+// encodes to equal bytes.
+func (rd *Round1Data) MarshalProto() ([]byte, error) {
+	var b []byte
+	b = appendProtoVarint(b, 1, uint64(rd.PartyID))
+	for _, row := range rd.D {
+		var rowBytes []byte
+		for _, p := range row {
+			rowBytes = appendProtoBytes(rowBytes, 1, packPoly(p))
+		}
+		b = appendProtoBytes(b, 2, rowBytes)
+	}
+	recipients := make([]int, 0, len(rd.MACs))
+	for j := range rd.MACs {
+		recipients = append(recipients, j)
+	}
+	sort.Ints(recipients)
+	for _, j := range recipients {
+		var entry []byte
+		entry = appendProtoVarint(entry, 1, uint64(j))
+		entry = appendProtoBytes(entry, 2, rd.MACs[j])
+		b = appendProtoBytes(b, 3, entry)
+	}
+	return b, nil
+}
+
+// UnmarshalProto decodes a Round1Data message produced by MarshalProto.
+func (rd *Round1Data) UnmarshalProto(data []byte) error {
+	var decoded Round1Data
+	decoded.MACs = make(map[int][]byte)
+	err := forEachProtoField(data, func(num, wireType int, v uint64, b []byte) error {
+		switch {
+		case num == 1 && wireType == protoVarint:
+			decoded.PartyID = int(uint32(v))
+		case num == 2 && wireType == protoBytes:
+			var row structs.Vector[ring.Poly]
+			err := forEachProtoField(b, func(num, wireType int, _ uint64, b []byte) error {
+				if num != 1 || wireType != protoBytes {
+					return nil
+				}
+				p, err := unpackPoly(b, sign.Q)
+				row = append(row, p)
+				return err
+			})
+			if err != nil {
+				return err
+			}
+			decoded.D = append(decoded.D, row)
+		case num == 3 && wireType == protoBytes:
+			var recipient int
+			var mac []byte
+			err := forEachProtoField(b, func(num, wireType int, v uint64, b []byte) error {
+				switch {
+				case num == 1 && wireType == protoVarint:
+					recipient = int(uint32(v))
+				case num == 2 && wireType == protoBytes:
+					mac = append([]byte(nil), b...)
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			decoded.MACs[recipient] = mac
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	*rd = decoded
+	return nil
+}
+
+// MarshalProto encodes the z share as the Round2Data message of ringtail.proto.
+func (rd *Round2Data) MarshalProto() ([]byte, error) {
+	var b []byte
+	b = appendProtoVarint(b, 1, uint64(rd.PartyID))
+	for _, p := range rd.Z {
+		b = appendProtoBytes(b, 2, packPoly(p))
+	}
+	return b, nil
+}
+
+// UnmarshalProto decodes a Round2Data message produced by MarshalProto.
+func (rd *Round2Data) UnmarshalProto(data []byte) error {
+	var decoded Round2Data
+	err := forEachProtoField(data, func(num, wireType int, v uint64, b []byte) error {
+		switch {
+		case num == 1 && wireType == protoVarint:
+			decoded.PartyID = int(uint32(v))
+		case num == 2 && wireType == protoBytes:
+			p, err := unpackPoly(b, sign.Q)
+			if err != nil {
+				return err
+			}
+			decoded.Z = append(decoded.Z, p)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	*rd = decoded
+	return nil
+}
+
+// MarshalProto encodes the signature as the Signature message of ringtail.proto.
+func (sig *Signature) MarshalProto() ([]byte, error) {
+	var b []byte
+	b = appendProtoBytes(b, 1, packPoly(sig.C))
+	for _, p := range sig.Z {
+		b = appendProtoBytes(b, 2, packPoly(p))
+	}
+	for _, p := range sig.Delta {
+		b = appendProtoBytes(b, 3, packPoly(p))
+	}
+	return b, nil
+}
+
+// UnmarshalProto decodes a Signature message produced by MarshalProto.
+func (sig *Signature) UnmarshalProto(data []byte) error {
+	var decoded Signature
+	hasC := false
+	err := forEachProtoField(data, func(num, wireType int, _ uint64, b []byte) error {
+		if wireType != protoBytes {
+			return nil
+		}
+		var err error
+		var p ring.Poly
+		switch num {
+		case 1:
+			decoded.C, err = unpackPoly(b, sign.Q)
+			hasC = true
+		case 2:
+			p, err = unpackPoly(b, sign.Q)
+			decoded.Z = append(decoded.Z, p)
+		case 3:
+			p, err = unpackPoly(b, sign.QNu)
+			decoded.Delta = append(decoded.Delta, p)
+		}
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if !hasC {
+		return fmt.Errorf("%w: signature has no challenge", ErrInvalidEncoding)
+	}
+	*sig = decoded
+	return nil
+}
+
+// packPoly returns the coefficients of p as little-endian uint64s.
+func packPoly(p ring.Poly) []byte {
+	coeffs := p.Coeffs[0]
+	b := make([]byte, 8*len(coeffs))
+	for i, c := range coeffs {
+		utils.CoefficientByteOrder().PutUint64(b[8*i:], c)
+	}
+	return b
+}
+
+// unpackPoly decodes a polynomial packed by packPoly, rejecting any length
+// other than N coefficients and any coefficient not reduced modulo q.
+func unpackPoly(b []byte, q uint64) (ring.Poly, error) {
+	n := 1 << sign.LogN
+	if len(b) != 8*n {
+		return ring.Poly{}, fmt.Errorf("%w: polynomial of %d bytes, want %d", ErrInvalidEncoding, len(b), 8*n)
+	}
+	p := ring.NewPoly(n, 0)
+	for i := range p.Coeffs[0] {
+		c := utils.CoefficientByteOrder().Uint64(b[8*i:])
+		if c >= q {
+			return ring.Poly{}, fmt.Errorf("%w: coefficient %d not reduced", ErrInvalidEncoding, i)
+		}
+		p.Coeffs[0][i] = c
+	}
+	return p, nil
+}
+
+func appendProtoVarint(b []byte, num int, v uint64) []byte {
+	if v == 0 {
+		return b
+	}
+	b = binary.AppendUvarint(b, uint64(num)<<3|protoVarint)
+	return binary.AppendUvarint(b, v)
+}
+
+func appendProtoBytes(b []byte, num int, v []byte) []byte {
+	b = binary.AppendUvarint(b, uint64(num)<<3|protoBytes)
+	b = binary.AppendUvarint(b, uint64(len(v)))
+	return append(b, v...)
+}
+
+// forEachProtoField calls fn for each field of the protobuf message in data,
+// with the value of varint fields in v and the payload of length-delimited
+// fields in b. Fixed-width fields, which the schema does not use, are skipped.
+func forEachProtoField(data []byte, fn func(num, wireType int, v uint64, b []byte) error) error {
+	for len(data) > 0 {
+		key, n := binary.Uvarint(data)
+		if n <= 0 || key>>3 == 0 {
+			return fmt.Errorf("%w: bad field key", ErrInvalidEncoding)
+		}
+		data = data[n:]
+		num, wireType := int(key>>3), int(key&7)
+
+		var v uint64
+		var b []byte
+		switch wireType {
+		case protoVarint:
+			if v, n = binary.Uvarint(data); n <= 0 {
+				return fmt.Errorf("%w: bad varint in field %d", ErrInvalidEncoding, num)
+			}
+			data = data[n:]
+		case protoBytes:
+			length, n := binary.Uvarint(data)
+			if n <= 0 || length > uint64(len(data)-n) {
+				return fmt.Errorf("%w: bad length in field %d", ErrInvalidEncoding, num)
+			}
+			b, data = data[n:n+int(length)], data[n+int(length):]
+		case protoFixed64, protoFixed32:
+			width := 8
+			if wireType == protoFixed32 {
+				width = 4
+			}
+			if len(data) < width {
+				return fmt.Errorf("%w: truncated field %d", ErrInvalidEncoding, num)
+			}
+			data = data[width:]
+			continue
+		default:
+			return fmt.Errorf("%w: unsupported wire type %d", ErrInvalidEncoding, wireType)
+		}
+		if err := fn(num, wireType, v, b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+// Copyright (C) 2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package threshold
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/luxfi/ringtail/sign"
+	"github.com/luxfi/ringtail/utils"
+
+	"github.com/luxfi/lattice/v7/ring"
+	"github.com/luxfi/lattice/v7/utils/structs"
+)
+
+func TestProtoSigningFlow(t *testing.T) {
+	shares, groupKey, err := GenerateKeys(2, 3, nil)
+	if err != nil {
+		t.Fatalf("GenerateKeys failed: %v", err)
+	}
+	r := groupKey.Params.R
+	signers := newSigners(shares)
+	prfKey := []byte("test-prf-key-32-bytes-long!!!!!!")
+	signerIDs := []int{0, 2}
+	sessionID := 1
+	message := "protobuf"
+
+	// Every message crosses the wire as protobuf before the next round reads it.
+	round1Data := make(map[int]*Round1Data)
+	for _, id := range signerIDs {
+		sent := signers[id].Round1(sessionID, prfKey, signerIDs)
+		encoded, err := sent.MarshalProto()
+		if err != nil {
+			t.Fatalf("Round1Data.MarshalProto failed: %v", err)
+		}
+		var received Round1Data
+		if err := received.UnmarshalProto(encoded); err != nil {
+			t.Fatalf("Round1Data.UnmarshalProto failed: %v", err)
+		}
+		if received.PartyID != sent.PartyID || len(received.D) != len(sent.D) || len(received.MACs) != len(sent.MACs) {
+			t.Fatalf("Round1Data changed shape in transit")
+		}
+		for i := range sent.D {
+			if !utils.VectorEqual(r, received.D[i], sent.D[i]) {
+				t.Errorf("row %d of D changed in transit", i)
+			}
+		}
+		for j, mac := range sent.MACs {
+			if !bytes.Equal(received.MACs[j], mac) {
+				t.Errorf("MAC for party %d changed in transit", j)
+			}
+		}
+		round1Data[received.PartyID] = &received
+	}
+
+	round2Data := make(map[int]*Round2Data)
+	for _, id := range signerIDs {
+		sent, err := signers[id].Round2(sessionID, message, prfKey, signerIDs, round1Data)
+		if err != nil {
+			t.Fatalf("Round2 failed: %v", err)
+		}
+		encoded, err := sent.MarshalProto()
+		if err != nil {
+			t.Fatalf("Round2Data.MarshalProto failed: %v", err)
+		}
+		var received Round2Data
+		if err := received.UnmarshalProto(encoded); err != nil {
+			t.Fatalf("Round2Data.UnmarshalProto failed: %v", err)
+		}
+		if received.PartyID != sent.PartyID || !utils.VectorEqual(r, received.Z, sent.Z) {
+			t.Errorf("Round2Data of party %d changed in transit", id)
+		}
+		round2Data[received.PartyID] = &received
+	}
+
+	sig, err := signers[signerIDs[0]].Finalize(round2Data)
+	if err != nil {
+		t.Fatalf("Finalize failed: %v", err)
+	}
+	encoded, err := sig.MarshalProto()
+	if err != nil {
+		t.Fatalf("Signature.MarshalProto failed: %v", err)
+	}
+	var received Signature
+	if err := received.UnmarshalProto(encoded); err != nil {
+		t.Fatalf("Signature.UnmarshalProto failed: %v", err)
+	}
+	if !utils.PolyEqual(r, received.C, sig.C) || !utils.VectorEqual(r, received.Z, sig.Z) ||
+		!utils.VectorEqual(groupKey.Params.RNu, received.Delta, sig.Delta) {
+		t.Error("Signature changed in transit")
+	}
+	if !Verify(groupKey, message, &received) {
+		t.Error("signature decoded from protobuf did not verify")
+	}
+}
+
+func TestProtoWireFormat(t *testing.T) {
+	params, err := NewParams()
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := params.R
+	p := r.NewPoly()
+	p.Coeffs[0][0] = 0x0102
+
+	// party_id = 1, then z as a length-delimited field of 2048 bytes.
+	encoded, err := (&Round2Data{PartyID: 1, Z: structs.Vector[ring.Poly]{p}}).MarshalProto()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []byte{0x08, 0x01, 0x12, 0x80, 0x10, 0x02, 0x01, 0x00}
+	if !bytes.HasPrefix(encoded, want) || len(encoded) != 5+8*r.N() {
+		t.Fatalf("encoding starts % x with length %d, want prefix % x and length %d", encoded[:len(want)], len(encoded), want, 5+8*r.N())
+	}
+
+	// Party 0 omits the default party_id, as proto3 does.
+	zero, _ := (&Round2Data{Z: structs.Vector[ring.Poly]{p}}).MarshalProto()
+	if !bytes.Equal(zero, encoded[2:]) {
+		t.Error("party 0 did not omit party_id")
+	}
+
+	// Unknown fields from a newer schema are skipped.
+	withUnknown := append(append([]byte(nil), encoded...), 0x20, 0x07, 0x29, 1, 2, 3, 4, 5, 6, 7, 8)
+	var decoded Round2Data
+	if err := decoded.UnmarshalProto(withUnknown); err != nil || decoded.PartyID != 1 || !r.Equal(decoded.Z[0], p) {
+		t.Errorf("decoding with unknown fields: %v", err)
+	}
+
+	unreduced := append([]byte(nil), encoded...)
+	utils.CoefficientByteOrder().PutUint64(unreduced[5:], sign.Q)
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{name: "truncated", data: encoded[:len(encoded)-1]},
+		{name: "short polynomial", data: append([]byte{0x08, 0x01, 0x12, 0x08}, make([]byte, 8)...)},
+		{name: "unreduced coefficient", data: unreduced},
+		{name: "bad key", data: []byte{0x00}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var rd Round2Data
+			if err := rd.UnmarshalProto(tt.data); !errors.Is(err, ErrInvalidEncoding) {
+				t.Errorf("expected ErrInvalidEncoding, got %v", err)
+			}
+		})
+	}
+
+	var sig Signature
+	if err := sig.UnmarshalProto(nil); !errors.Is(err, ErrInvalidEncoding) {
+		t.Errorf("empty signature: expected ErrInvalidEncoding, got %v", err)
+	}
+}
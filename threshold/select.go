@@ -0,0 +1,48 @@
+// Copyright (C) 2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package threshold
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+var ErrNotEnoughSigners = errors.New("not enough available signers")
+
+// SelectSigners picks threshold parties out of available to sign with, for
+// keys from GenerateKeys where every party has weight one. Parties with a
+// lower preference value, such as a measured latency, are picked first, and
+// ties go to the lower index; a nil preference picks the lowest indices. The
+// result is in preference order.
+func SelectSigners(available []int, threshold int, preference func(int) int) ([]int, error) {
+	if threshold < 1 {
+		return nil, ErrInvalidThreshold
+	}
+	if len(available) < threshold {
+		return nil, fmt.Errorf("%w: %d available, need %d", ErrNotEnoughSigners, len(available), threshold)
+	}
+
+	rank := make(map[int]int, len(available))
+	for _, j := range available {
+		if j < 0 {
+			return nil, fmt.Errorf("%w: %d", ErrInvalidPartyIndex, j)
+		}
+		if _, ok := rank[j]; ok {
+			return nil, fmt.Errorf("%w: duplicate signer %d", ErrInvalidPartyIndex, j)
+		}
+		if preference != nil {
+			rank[j] = preference(j)
+		} else {
+			rank[j] = 0
+		}
+	}
+
+	selected := slices.Clone(available)
+	slices.SortFunc(selected, func(a, b int) int {
+		return cmp.Or(cmp.Compare(rank[a], rank[b]), cmp.Compare(a, b))
+	})
+	return selected[:threshold:threshold], nil
+}
@@ -0,0 +1,71 @@
+// Copyright (C) 2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package threshold
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestSelectSigners(t *testing.T) {
+	latency := map[int]int{0: 120, 1: 15, 2: 80, 3: 15, 4: 300, 5: 40}
+	byLatency := func(j int) int { return latency[j] }
+
+	tests := []struct {
+		name       string
+		available  []int
+		threshold  int
+		preference func(int) int
+		want       []int
+		wantErr    error
+	}{
+		{name: "lowest latency", available: []int{0, 1, 2, 3, 4, 5}, threshold: 3, preference: byLatency, want: []int{1, 3, 5}},
+		{name: "latency ties by index", available: []int{5, 4, 3, 2, 1, 0}, threshold: 2, preference: byLatency, want: []int{1, 3}},
+		{name: "lowest index", available: []int{4, 2, 5, 0}, threshold: 2, want: []int{0, 2}},
+		{name: "exact pool", available: []int{4, 0}, threshold: 2, preference: byLatency, want: []int{0, 4}},
+		{name: "pool too small", available: []int{0, 1}, threshold: 3, wantErr: ErrNotEnoughSigners},
+		{name: "zero threshold", available: []int{0, 1}, threshold: 0, wantErr: ErrInvalidThreshold},
+		{name: "duplicate party", available: []int{0, 1, 1}, threshold: 2, wantErr: ErrInvalidPartyIndex},
+		{name: "negative party", available: []int{0, -1, 2}, threshold: 2, wantErr: ErrInvalidPartyIndex},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			available := slices.Clone(tt.available)
+			got, err := SelectSigners(available, tt.threshold, tt.preference)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SelectSigners failed: %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			if !slices.Equal(available, tt.available) {
+				t.Errorf("SelectSigners reordered its input to %v", available)
+			}
+		})
+	}
+
+	shares, groupKey, err := GenerateKeys(3, 6, nil)
+	if err != nil {
+		t.Fatalf("GenerateKeys failed: %v", err)
+	}
+	signerIDs, err := SelectSigners([]int{0, 1, 2, 3, 4, 5}, groupKey.Threshold, byLatency)
+	if err != nil {
+		t.Fatalf("SelectSigners failed: %v", err)
+	}
+	sig, err := signSession(newSigners(shares), signerIDs, 1, "selected")
+	if err != nil {
+		t.Fatalf("signing with %v failed: %v", signerIDs, err)
+	}
+	if !Verify(groupKey, "selected", sig) {
+		t.Errorf("signature by selected signers %v did not verify", signerIDs)
+	}
+}
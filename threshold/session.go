@@ -0,0 +1,173 @@
+// Copyright (C) 2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package threshold
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/luxfi/ringtail/primitives"
+)
+
+var (
+	ErrSessionExists  = errors.New("session already open")
+	ErrUnknownSession = errors.New("unknown session")
+)
+
+// SessionInfo describes an open signing session.
+type SessionInfo struct {
+	ID      int
+	Epoch   uint64 // Epoch of the group key the session signs under
+	Round   int    // Last round completed: 0 when opened, then 1 or 2
+	Signers []int  // Canonical signer set
+	Age     time.Duration
+}
+
+// session is one open signing session. Each has its own Signer, since a
+// Signer holds the Round 1 state of a single session at a time.
+type session struct {
+	epoch   uint64
+	signer  *Signer
+	signers []int
+	round   int
+	started time.Time
+}
+
+// SessionManager runs concurrent signing sessions for one party and tracks
+// them until they are closed, so an operator can drain them before restart.
+type SessionManager struct {
+	mu       sync.Mutex
+	now      func() time.Time
+	sessions map[int]*session
+}
+
+// NewSessionManager returns an empty SessionManager.
+func NewSessionManager() *SessionManager {
+	return &SessionManager{
+		now:      time.Now,
+		sessions: make(map[int]*session),
+	}
+}
+
+// Open starts tracking sessionID, signed by share's party with signers under
+// the group key of epoch. It fails if share does not pass Validate.
+func (m *SessionManager) Open(sessionID int, epoch uint64, share *KeyShare, signers []int) error {
+	if err := share.Validate(); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.sessions[sessionID]; ok {
+		return fmt.Errorf("%w: %d", ErrSessionExists, sessionID)
+	}
+	m.sessions[sessionID] = &session{
+		epoch:   epoch,
+		signer:  NewSigner(share),
+		signers: primitives.CanonicalSignerSet(signers),
+		started: m.now(),
+	}
+	return nil
+}
+
+// Round1 runs signing round 1 for sessionID.
+func (m *SessionManager) Round1(sessionID int, prfKey []byte) (*Round1Data, error) {
+	sess, err := m.session(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	data := sess.signer.Round1(sessionID, prfKey, sess.signers)
+	m.advance(sess, 1)
+	return data, nil
+}
+
+// Round2 runs signing round 2 for sessionID.
+func (m *SessionManager) Round2(sessionID int, message string, prfKey []byte, round1Data map[int]*Round1Data) (*Round2Data, error) {
+	sess, err := m.session(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	data, err := sess.signer.Round2(sessionID, message, prfKey, sess.signers, round1Data)
+	if err != nil {
+		return nil, err
+	}
+	m.advance(sess, 2)
+	return data, nil
+}
+
+// Signer returns the signer of sessionID, for Finalize and PartialVerify.
+func (m *SessionManager) Signer(sessionID int) (*Signer, error) {
+	sess, err := m.session(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	return sess.signer, nil
+}
+
+// Close aborts sessionID, clearing its Round 1 secrets, and stops tracking it.
+func (m *SessionManager) Close(sessionID int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.closeLocked(sessionID)
+}
+
+// CancelEpoch closes every session bound to epoch, clearing their Round 1
+// secrets, once a new epoch's group key supersedes it. It returns how many
+// sessions were closed.
+func (m *SessionManager) CancelEpoch(epoch uint64) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	canceled := 0
+	for id, sess := range m.sessions {
+		if sess.epoch == epoch {
+			m.closeLocked(id)
+			canceled++
+		}
+	}
+	return canceled
+}
+
+// ActiveSessions returns a snapshot of the open sessions in session ID order.
+func (m *SessionManager) ActiveSessions() []SessionInfo {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := m.now()
+	infos := make([]SessionInfo, 0, len(m.sessions))
+	for id, sess := range m.sessions {
+		infos = append(infos, SessionInfo{
+			ID:      id,
+			Epoch:   sess.epoch,
+			Round:   sess.round,
+			Signers: append([]int(nil), sess.signers...),
+			Age:     now.Sub(sess.started),
+		})
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].ID < infos[j].ID })
+	return infos
+}
+
+func (m *SessionManager) session(sessionID int) (*session, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	sess, ok := m.sessions[sessionID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %d", ErrUnknownSession, sessionID)
+	}
+	return sess, nil
+}
+
+func (m *SessionManager) advance(sess *session, round int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	sess.round = round
+}
+
+func (m *SessionManager) closeLocked(sessionID int) {
+	if sess, ok := m.sessions[sessionID]; ok {
+		sess.signer.AbortSession(sessionID)
+		delete(m.sessions, sessionID)
+	}
+}
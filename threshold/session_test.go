@@ -0,0 +1,115 @@
+// Copyright (C) 2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package threshold
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestActiveSessions(t *testing.T) {
+	shares, _, err := GenerateKeys(2, 3, nil)
+	if err != nil {
+		t.Fatalf("GenerateKeys failed: %v", err)
+	}
+	prfKey := []byte("test-prf-key-32-bytes-long!!!!!!")
+
+	m := NewSessionManager()
+	clock := time.Unix(1000, 0)
+	m.now = func() time.Time { return clock }
+
+	// Session 1 is only opened, session 2 reaches round 1, session 3 round 2.
+	for id, signers := range map[int][]int{1: {0, 1}, 2: {2, 0}, 3: {0, 1}} {
+		if err := m.Open(id, 7, shares[0], signers); err != nil {
+			t.Fatalf("Open(%d) failed: %v", id, err)
+		}
+	}
+	if err := m.Open(1, 7, shares[0], []int{0, 1}); !errors.Is(err, ErrSessionExists) {
+		t.Fatalf("expected ErrSessionExists, got %v", err)
+	}
+	clock = clock.Add(time.Minute)
+
+	if _, err := m.Round1(2, prfKey); err != nil {
+		t.Fatalf("Round1(2) failed: %v", err)
+	}
+	own, err := m.Round1(3, prfKey)
+	if err != nil {
+		t.Fatalf("Round1(3) failed: %v", err)
+	}
+	peer := NewSigner(shares[1])
+	round1Data := map[int]*Round1Data{0: own, 1: peer.Round1(3, prfKey, []int{0, 1})}
+	if _, err := m.Round2(3, "drain", prfKey, round1Data); err != nil {
+		t.Fatalf("Round2(3) failed: %v", err)
+	}
+	if _, err := m.Round1(4, prfKey); !errors.Is(err, ErrUnknownSession) {
+		t.Fatalf("expected ErrUnknownSession, got %v", err)
+	}
+
+	want := []SessionInfo{
+		{ID: 1, Epoch: 7, Round: 0, Signers: []int{0, 1}, Age: time.Minute},
+		{ID: 2, Epoch: 7, Round: 1, Signers: []int{0, 2}, Age: time.Minute},
+		{ID: 3, Epoch: 7, Round: 2, Signers: []int{0, 1}, Age: time.Minute},
+	}
+	got := m.ActiveSessions()
+	if len(got) != len(want) {
+		t.Fatalf("got %d sessions, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i].ID != want[i].ID || got[i].Epoch != want[i].Epoch || got[i].Round != want[i].Round || got[i].Age != want[i].Age ||
+			!slices.Equal(got[i].Signers, want[i].Signers) {
+			t.Errorf("session %d: got %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	m.Close(2)
+	if got := m.ActiveSessions(); len(got) != 2 || got[1].ID != 3 {
+		t.Errorf("after Close(2) got %+v", got)
+	}
+}
+
+func TestCancelEpoch(t *testing.T) {
+	shares, _, err := GenerateKeys(2, 3, nil)
+	if err != nil {
+		t.Fatalf("GenerateKeys failed: %v", err)
+	}
+	prfKey := []byte("test-prf-key-32-bytes-long!!!!!!")
+
+	m := NewSessionManager()
+	epochs := map[int]uint64{1: 1, 2: 1, 3: 2, 4: 2}
+	for id, epoch := range epochs {
+		if err := m.Open(id, epoch, shares[0], []int{0, 1}); err != nil {
+			t.Fatalf("Open(%d) failed: %v", id, err)
+		}
+		if _, err := m.Round1(id, prfKey); err != nil {
+			t.Fatalf("Round1(%d) failed: %v", id, err)
+		}
+	}
+	canceledSigner, err := m.Signer(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := m.CancelEpoch(1); got != 2 {
+		t.Errorf("CancelEpoch(1) closed %d sessions, want 2", got)
+	}
+	for _, info := range m.ActiveSessions() {
+		if info.Epoch != 2 {
+			t.Errorf("session %d of epoch %d survived CancelEpoch(1)", info.ID, info.Epoch)
+		}
+	}
+	if got := len(m.ActiveSessions()); got != 2 {
+		t.Errorf("%d sessions left, want 2", got)
+	}
+	if canceledSigner.party.R != nil {
+		t.Error("canceled session still holds its Round 1 secret")
+	}
+	if _, err := m.Round1(1, prfKey); !errors.Is(err, ErrUnknownSession) {
+		t.Errorf("expected ErrUnknownSession, got %v", err)
+	}
+	if got := m.CancelEpoch(1); got != 0 {
+		t.Errorf("second CancelEpoch(1) closed %d sessions, want 0", got)
+	}
+}
@@ -0,0 +1,32 @@
+// Copyright (C) 2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package threshold
+
+import (
+	"io"
+
+	"github.com/zeebo/blake3"
+)
+
+// prehashTag prefixes the digest of a prehashed message. Messages beginning
+// with it are reserved like those beginning with expiryTag.
+const prehashTag = "RingtailPrehashV1\x00"
+
+// PrehashMessage streams msg to the end and returns a short stand-in for it:
+// prehashTag followed by the BLAKE3-256 digest of the bytes. It is the way to
+// sign a message too large to hold in memory: sign the result with Round2 and
+// check it with Verify over PrehashMessage of the same bytes. The signature is
+// over the digest, so it does not verify for the raw message, and every
+// signer must prehash.
+//
+// The signing transcript cannot take a reader directly: the challenge hash
+// absorbs the message after h, which depends on a hash of the message itself,
+// so no single pass feeds every hasher.
+func PrehashMessage(msg io.Reader) (string, error) {
+	hasher := blake3.New()
+	if _, err := io.Copy(hasher, msg); err != nil {
+		return "", err
+	}
+	return prehashTag + string(hasher.Sum(nil)), nil
+}
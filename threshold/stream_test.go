@@ -0,0 +1,56 @@
+// Copyright (C) 2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package threshold
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+	"testing/iotest"
+)
+
+func TestPrehashMessage(t *testing.T) {
+	shares, groupKey, err := GenerateKeys(2, 3, nil)
+	if err != nil {
+		t.Fatalf("GenerateKeys failed: %v", err)
+	}
+	signers := newSigners(shares)
+	message := bytes.Repeat([]byte("streamed message "), 1<<18) // ~4 MiB
+
+	prehashed, err := PrehashMessage(bytes.NewReader(message))
+	if err != nil {
+		t.Fatalf("PrehashMessage failed: %v", err)
+	}
+	if again, _ := PrehashMessage(iotest.OneByteReader(bytes.NewReader(message[:1<<12]))); again == prehashed {
+		t.Fatal("prehash of a prefix matches the whole message")
+	}
+	sig, err := signSession(signers, []int{0, 1, 2}, 1, prehashed)
+	if err != nil {
+		t.Fatalf("signing failed: %v", err)
+	}
+
+	verifyStreamed := func(msg io.Reader) bool {
+		t.Helper()
+		digest, err := PrehashMessage(msg)
+		return err == nil && Verify(groupKey, digest, sig)
+	}
+	if !verifyStreamed(bytes.NewReader(message)) {
+		t.Error("signature rejected over the prehash of the same bytes")
+	}
+	if Verify(groupKey, string(message), sig) {
+		t.Error("signature over the prehash verified for the raw message")
+	}
+
+	tampered := append([]byte(nil), message...)
+	tampered[len(tampered)-1] ^= 1
+	if verifyStreamed(bytes.NewReader(tampered)) {
+		t.Error("signature accepted for a different message")
+	}
+
+	failing := io.MultiReader(bytes.NewReader(message[:10]), iotest.ErrReader(errors.New("read failed")))
+	if _, err := PrehashMessage(failing); err == nil {
+		t.Error("PrehashMessage ignored a read error")
+	}
+}
@@ -12,17 +12,23 @@
 package threshold
 
 import (
+	"context"
 	"crypto/rand"
 	"errors"
+	"fmt"
 	"io"
 	"math/big"
+	"runtime"
+	"sync"
 
 	"github.com/luxfi/ringtail/primitives"
 	"github.com/luxfi/ringtail/sign"
+	"github.com/luxfi/ringtail/utils"
 
 	"github.com/luxfi/lattice/v7/ring"
 	"github.com/luxfi/lattice/v7/utils/sampling"
 	"github.com/luxfi/lattice/v7/utils/structs"
+	"github.com/zeebo/blake3"
 )
 
 var (
@@ -32,8 +38,27 @@ var (
 	ErrMACVerifyFailed   = errors.New("MAC verification failed")
 	ErrFullRankFailed    = errors.New("full rank check failed")
 	ErrInsufficientData  = errors.New("insufficient round data")
+
+	ErrCommitmentMismatch = errors.New("contribution does not match commitment")
+
+	ErrSignatureRejected       = errors.New("signature rejected by norm bound")
+	ErrRejectionBudgetExceeded = errors.New("rejection budget exceeded")
+
+	ErrSessionAborted    = errors.New("signing session aborted")
+	ErrSessionNotPending = errors.New("no round 1 state for session")
+
+	ErrInvalidWeight      = errors.New("party weight must be > 0")
+	ErrInsufficientWeight = errors.New("signer weight below threshold")
+
+	ErrInvalidKeyShare = errors.New("invalid key share")
+
+	ErrInvalidParams = errors.New("invalid ring parameters")
+	ErrInvalidMember = errors.New("invalid member identifier")
 )
 
+// DefaultMaxRejectionRetries is the rejection budget of a new Signer.
+const DefaultMaxRejectionRetries = 16
+
 // Params holds ring parameters for the protocol.
 type Params struct {
 	R   *ring.Ring // Main ring with prime Q
@@ -41,43 +66,103 @@ type Params struct {
 	RNu *ring.Ring // Rounding ring with QNu
 }
 
+// DefaultPreset names the parameter set the protocol runs with.
+const DefaultPreset = "Ringtail-128"
+
 // NewParams creates ring parameters.
 func NewParams() (*Params, error) {
-	r, err := ring.NewRing(1<<sign.LogN, []uint64{sign.Q})
+	preset, err := sign.NewParamsPreset(DefaultPreset)
 	if err != nil {
 		return nil, err
 	}
-	// QXi and QNu are powers of 2 for rounding, ignore ring errors
-	rXi, _ := ring.NewRing(1<<sign.LogN, []uint64{sign.QXi})
-	rNu, _ := ring.NewRing(1<<sign.LogN, []uint64{sign.QNu})
+	return NewParamsFromPreset(preset)
+}
+
+// NewParamsFromPreset creates ring parameters for a named parameter set. Only
+// the preset's ring degree and moduli are used; see sign.Preset. The signing
+// code assumes sign.LogN, sign.Q, sign.QXi and sign.QNu (norm centering,
+// rounding and the wire encodings), so a preset with any other value is
+// rejected with ErrInvalidParams.
+func NewParamsFromPreset(preset *sign.Preset) (*Params, error) {
+	r, rXi, rNu, err := preset.Rings()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidParams, err)
+	}
+	if preset.LogN != sign.LogN || preset.Q != sign.Q || preset.QXi != sign.QXi || preset.QNu != sign.QNu {
+		return nil, fmt.Errorf("%w: LogN=%d Q=%d QXi=%d QNu=%d, the signing code only implements LogN=%d Q=%d QXi=%d QNu=%d",
+			ErrInvalidParams, preset.LogN, preset.Q, preset.QXi, preset.QNu, sign.LogN, uint64(sign.Q), sign.QXi, sign.QNu)
+	}
 	return &Params{R: r, RXi: rXi, RNu: rNu}, nil
 }
 
 // GroupKey holds the public parameters for the threshold group.
 type GroupKey struct {
-	A      structs.Matrix[ring.Poly] // Public matrix
-	BTilde structs.Vector[ring.Poly] // Rounded public key
-	Params *Params
-}
-
-// Bytes returns a serialized representation of the group key.
-// Note: This is a simplified serialization for compatibility.
-func (gk *GroupKey) Bytes() []byte {
-	if gk == nil || gk.BTilde == nil {
-		return nil
-	}
-	// Return size info as a simple representation
-	return []byte{byte(len(gk.A)), byte(len(gk.BTilde))}
+	A         structs.Matrix[ring.Poly] // Public matrix
+	BTilde    structs.Vector[ring.Poly] // Rounded public key
+	Params    *Params
+	Weights   []int // Share slots held by each party
+	Threshold int   // Total weight a signer set must reach
+
+	// SlotKeys[k] = A·s_k + e_k is the public key of share slot k, with fresh
+	// noise e_k, in NTT and Montgomery form. PartialVerify checks z shares
+	// against it.
+	SlotKeys []structs.Vector[ring.Poly]
+
+	// Members[i] is the public identifier of party i, such as its validator
+	// key, when the group was generated with GenerateKeysWithMembers.
+	// Fingerprint binds it; signing and Verify ignore it.
+	Members [][]byte
+
+	b structs.Vector[ring.Poly] // Public key before rounding, in the coefficient domain
 }
 
 // KeyShare holds a party's secret share data.
 type KeyShare struct {
-	Index    int
-	SkShare  structs.Vector[ring.Poly]
-	Seeds    map[int][][]byte
-	MACKeys  map[int][]byte
-	Lambda   ring.Poly // Lagrange coefficient
-	GroupKey *GroupKey
+	Index      int
+	SkShare    structs.Vector[ring.Poly] // Share of the first slot in Slots
+	Seeds      map[int][][]byte
+	MACKeys    map[int][]byte
+	Lambda     ring.Poly // Lagrange coefficient of SkShare over all slots, in NTT and Montgomery form
+	GroupKey   *GroupKey
+	Slots      []int                       // Share slots held by this party
+	SlotShares []structs.Vector[ring.Poly] // Share of each slot, in NTT and Montgomery form
+}
+
+// LambdaStandard returns the Lagrange coefficient in the coefficient domain and
+// outside Montgomery form, the representation an external verifier computes.
+// Lambda itself is kept in the lattice-internal NTT and Montgomery form.
+func (ks *KeyShare) LambdaStandard() ring.Poly {
+	r := ks.GroupKey.Params.R
+	lambda := *ks.Lambda.CopyNew()
+	r.IMForm(lambda, lambda)
+	r.INTT(lambda, lambda)
+	return lambda
+}
+
+// Validate checks that the share fits its group key: its index is a party of
+// the group, it holds one share per slot of that party, and every share has
+// one polynomial per column of A, as A·s requires.
+func (ks *KeyShare) Validate() error {
+	gk := ks.GroupKey
+	if gk == nil || len(gk.A) == 0 {
+		return fmt.Errorf("%w: no group key", ErrInvalidKeyShare)
+	}
+	if ks.Index < 0 || ks.Index >= len(gk.Weights) {
+		return fmt.Errorf("%w: index %d of %d parties", ErrInvalidKeyShare, ks.Index, len(gk.Weights))
+	}
+	cols := len(gk.A[0])
+	if len(ks.SkShare) != cols {
+		return fmt.Errorf("%w: share has %d polynomials, A has %d columns", ErrInvalidKeyShare, len(ks.SkShare), cols)
+	}
+	if weight := gk.Weights[ks.Index]; len(ks.Slots) != weight || len(ks.SlotShares) != weight {
+		return fmt.Errorf("%w: %d slots and %d slot shares for weight %d", ErrInvalidKeyShare, len(ks.Slots), len(ks.SlotShares), weight)
+	}
+	for k, slotShare := range ks.SlotShares {
+		if len(slotShare) != cols {
+			return fmt.Errorf("%w: slot %d share has %d polynomials, A has %d columns", ErrInvalidKeyShare, ks.Slots[k], len(slotShare), cols)
+		}
+	}
+	return nil
 }
 
 // Round1Data holds a party's Round 1 output.
@@ -101,22 +186,102 @@ type Signature struct {
 }
 
 // GenerateKeys generates threshold key shares for n parties with threshold t.
-// This runs once per epoch when the validator set changes.
+// Any t of the n parties can sign. This runs once per epoch when the
+// validator set changes.
 func GenerateKeys(t, n int, randSource io.Reader) ([]*KeyShare, *GroupKey, error) {
+	weights, err := unitWeights(t, n)
+	if err != nil {
+		return nil, nil, err
+	}
+	return GenerateWeightedKeys(weights, t, randSource)
+}
+
+// GenerateKeysWithParams is GenerateKeys over the rings of params, such as
+// those of NewParamsFromPreset.
+func GenerateKeysWithParams(params *Params, t, n int, randSource io.Reader) ([]*KeyShare, *GroupKey, error) {
+	weights, err := unitWeights(t, n)
+	if err != nil {
+		return nil, nil, err
+	}
+	return generateKeys(params, weights, t, nil, randSource)
+}
+
+// GenerateKeysWithMembers is GenerateKeys for one party per entry of members,
+// recording the public identifier of each party in GroupKey.Members so the
+// group's Fingerprint commits to its membership. Identifiers must be
+// non-empty and distinct.
+func GenerateKeysWithMembers(t int, members [][]byte, randSource io.Reader) ([]*KeyShare, *GroupKey, error) {
+	seen := make(map[string]int, len(members))
+	for i, member := range members {
+		if len(member) == 0 {
+			return nil, nil, fmt.Errorf("%w: party %d has an empty identifier", ErrInvalidMember, i)
+		}
+		if j, ok := seen[string(member)]; ok {
+			return nil, nil, fmt.Errorf("%w: parties %d and %d share an identifier", ErrInvalidMember, j, i)
+		}
+		seen[string(member)] = i
+	}
+	shares, groupKey, err := GenerateKeys(t, len(members), randSource)
+	if err != nil {
+		return nil, nil, err
+	}
+	groupKey.Members = make([][]byte, len(members))
+	for i, member := range members {
+		groupKey.Members[i] = append([]byte(nil), member...)
+	}
+	return shares, groupKey, nil
+}
+
+// unitWeights validates a t-of-n setup and returns weight one for every party.
+func unitWeights(t, n int) ([]int, error) {
 	if n < 2 {
-		return nil, nil, ErrInvalidPartyCount
+		return nil, ErrInvalidPartyCount
 	}
 	if t < 1 || t >= n {
-		return nil, nil, ErrInvalidThreshold
+		return nil, ErrInvalidThreshold
+	}
+	weights := make([]int, n)
+	for i := range weights {
+		weights[i] = 1
 	}
+	return weights, nil
+}
 
-	// Set global params (required by sign package)
-	sign.K = n
-	sign.Threshold = t
+// GenerateWeightedKeys generates key shares for len(weights) parties where
+// party i holds weights[i] share slots, for example in proportion to stake.
+// A set of signers can sign once the sum of their weights reaches threshold.
+func GenerateWeightedKeys(weights []int, threshold int, randSource io.Reader) ([]*KeyShare, *GroupKey, error) {
+	return generateKeys(nil, weights, threshold, nil, randSource)
+}
 
-	params, err := NewParams()
-	if err != nil {
-		return nil, nil, err
+// keygenMu serializes the part of keygen that runs on the sign package's
+// globals: K, Threshold and the precomputed dealer randomness.
+var keygenMu sync.Mutex
+
+// generateKeys deals weighted key shares under the public matrix A, or under
+// a matrix sampled from the dealer key if A is nil. A nil params builds the
+// default ones.
+func generateKeys(params *Params, weights []int, threshold int, A structs.Matrix[ring.Poly], randSource io.Reader) ([]*KeyShare, *GroupKey, error) {
+	n := len(weights)
+	if n < 2 {
+		return nil, nil, ErrInvalidPartyCount
+	}
+	totalWeight := 0
+	for _, w := range weights {
+		if w < 1 {
+			return nil, nil, ErrInvalidWeight
+		}
+		totalWeight += w
+	}
+	if threshold < 1 || threshold > totalWeight {
+		return nil, nil, ErrInvalidThreshold
+	}
+
+	if params == nil {
+		var err error
+		if params, err = NewParams(); err != nil {
+			return nil, nil, err
+		}
 	}
 
 	// Generate trusted dealer key
@@ -128,43 +293,79 @@ func GenerateKeys(t, n int, randSource io.Reader) ([]*KeyShare, *GroupKey, error
 		return nil, nil, err
 	}
 
-	prng, err := sampling.NewKeyedPRNG(trustedDealerKey)
+	if A == nil {
+		prng, err := sampling.NewKeyedPRNG(trustedDealerKey)
+		if err != nil {
+			return nil, nil, err
+		}
+		A = utils.SamplePolyMatrix(params.R, sign.M, sign.N, ring.NewUniformSampler(prng, params.R), true, true)
+	}
+
+	// Shamir polynomials are drawn from a stream keyed by the dealer key, so
+	// keygen stays reproducible from randSource.
+	hasher := blake3.New()
+	_, _ = hasher.Write([]byte(shamirStreamTag))
+	_, _ = hasher.Write(trustedDealerKey)
+	shamirStream := hasher.Digest()
+
+	keygenMu.Lock()
+	// Set global params (required by sign package)
+	sign.K = n
+	sign.Threshold = threshold
+	slotShares, seeds, macKeys, b := sign.GenForMatrix(params.R, A, trustedDealerKey,
+		func(s structs.Vector[ring.Poly]) map[int]structs.Vector[ring.Poly] {
+			return primitives.ShamirSecretSharingGeneralFrom(params.R, s, threshold, totalWeight, shamirStream)
+		})
+	keygenMu.Unlock()
+
+	bTilde := utils.RoundVector(params.R, params.RXi, b, sign.Xi)
+
+	slotKeys, err := slotPublicKeys(params.R, A, slotShares, shamirStream)
 	if err != nil {
 		return nil, nil, err
 	}
-	uniformSampler := ring.NewUniformSampler(prng, params.R)
 
-	// Compute Lagrange coefficients for all parties
-	T := make([]int, n)
-	for i := range T {
-		T[i] = i
+	groupKey := &GroupKey{
+		A:         A,
+		BTilde:    bTilde,
+		Params:    params,
+		Weights:   append([]int(nil), weights...),
+		Threshold: threshold,
+		SlotKeys:  slotKeys,
+		b:         b,
 	}
-	lagrangeCoeffs := primitives.ComputeLagrangeCoefficients(params.R, T, big.NewInt(int64(sign.Q)))
 
-	// Generate shares
-	A, skShares, seeds, macKeys, bTilde := sign.Gen(params.R, params.RXi, uniformSampler, trustedDealerKey, lagrangeCoeffs)
-
-	groupKey := &GroupKey{
-		A:      A,
-		BTilde: bTilde,
-		Params: params,
+	// Lagrange coefficients for all slots
+	allSlots := make([]int, totalWeight)
+	for i := range allSlots {
+		allSlots[i] = i
 	}
+	lagrangeCoeffs := primitives.ComputeLagrangeCoefficients(params.R, allSlots, params.R.Modulus())
 
 	shares := make([]*KeyShare, n)
 	for i := 0; i < n; i++ {
+		start, end := groupKey.slotRange(i)
+		slots := allSlots[start:end:end]
+		partySlotShares := make([]structs.Vector[ring.Poly], len(slots))
+		for k, slot := range slots {
+			partySlotShares[k] = slotShares[slot]
+		}
+
 		// Convert Lagrange coefficient to NTT form
 		lambda := params.R.NewPoly()
-		lambda.Copy(lagrangeCoeffs[i])
+		lambda.Copy(lagrangeCoeffs[start])
 		params.R.NTT(lambda, lambda)
 		params.R.MForm(lambda, lambda)
 
 		shares[i] = &KeyShare{
-			Index:    i,
-			SkShare:  skShares[i],
-			Seeds:    seeds,
-			MACKeys:  macKeys[i],
-			Lambda:   lambda,
-			GroupKey: groupKey,
+			Index:      i,
+			SkShare:    partySlotShares[0],
+			Seeds:      seeds,
+			MACKeys:    macKeys[i],
+			Lambda:     lambda,
+			GroupKey:   groupKey,
+			Slots:      slots,
+			SlotShares: partySlotShares,
 		}
 	}
 
@@ -173,12 +374,36 @@ func GenerateKeys(t, n int, randSource io.Reader) ([]*KeyShare, *GroupKey, error
 
 // Signer handles threshold signing for a single party.
 type Signer struct {
+	// MaxRejectionRetries bounds how many consecutive signatures of one
+	// message Finalize may reject before Round2 refuses to start another
+	// attempt at that message. Other messages are unaffected, and
+	// ResetRejections clears every budget. Zero disables the budget. Each
+	// retry must use a fresh session ID so Round 1 resamples.
+	MaxRejectionRetries int
+
+	// NormBound is the squared L2 bound Finalize enforces on (z, Delta).
+	// It defaults to sign.Bsquare, the bound Verify enforces; nil disables the check.
+	NormBound *big.Int
+
 	share  *KeyShare
 	party  *sign.Party
 	params *Params
+	logger utils.Logger
+
+	mu         sync.Mutex
+	pending    int // Session whose Round 1 state the party holds
+	hasPending bool
+	aborted    map[int]struct{}
+	partial    *partialSession              // Last completed Round 2, for PartialVerify
+	lambdas    map[string]map[int]ring.Poly // Slot Lagrange coefficients by signer set
+	rejections map[string]int               // Consecutive rejected signatures by message
+
+	prfKey        []byte // Key of sessions started with a nil prfKey, from RotatePRFKey
+	pendingPRFKey []byte // Key the pending session's Round 1 was bound to
 }
 
-// NewSigner creates a signer from a key share.
+// NewSigner creates a signer from a key share. The share is not checked here;
+// call KeyShare.Validate first, as Round2 refuses to sign with an invalid one.
 func NewSigner(share *KeyShare) *Signer {
 	params := share.GroupKey.Params
 	prng, _ := sampling.NewKeyedPRNG(make([]byte, sign.KeySize))
@@ -190,16 +415,38 @@ func NewSigner(share *KeyShare) *Signer {
 	party.MACKeys = share.MACKeys
 	party.Lambda = share.Lambda
 
+	normBound, _ := new(big.Int).SetString(sign.Bsquare, 10)
+
 	return &Signer{
-		share:  share,
-		party:  party,
-		params: params,
+		MaxRejectionRetries: DefaultMaxRejectionRetries,
+		NormBound:           normBound,
+		share:               share,
+		party:               party,
+		params:              params,
+		logger:              utils.NopLogger{},
+		aborted:             make(map[int]struct{}),
+		lambdas:             make(map[string]map[int]ring.Poly),
+		rejections:          make(map[string]int),
 	}
 }
 
+// SetLogger routes the signer's round progress and failure events to l.
+// A nil l restores the default no-op logger.
+func (s *Signer) SetLogger(l utils.Logger) {
+	if l == nil {
+		l = utils.NopLogger{}
+	}
+	s.logger = l
+}
+
 // Round1 performs signing round 1. Returns D matrix and MACs to broadcast.
+// The order of signers does not matter. The session is bound to prfKey, or
+// to the key of the last RotatePRFKey if prfKey is nil.
 func (s *Signer) Round1(sessionID int, prfKey []byte, signers []int) *Round1Data {
+	signers = primitives.CanonicalSignerSet(signers)
 	D, MACs := s.party.SignRound1(s.share.GroupKey.A, sessionID, prfKey, signers)
+	s.startPRFKey(sessionID, prfKey)
+	s.logger.Debug("round 1 complete", "party", s.share.Index, "session", sessionID, "signers", len(signers))
 	return &Round1Data{
 		PartyID: s.share.Index,
 		D:       D,
@@ -208,9 +455,68 @@ func (s *Signer) Round1(sessionID int, prfKey []byte, signers []int) *Round1Data
 }
 
 // Round2 performs signing round 2. Returns z share to broadcast.
-// round1Data is the collected Round 1 data from all signers.
+// round1Data is the collected Round 1 data from all signers. A nil prfKey
+// selects the key the session's Round 1 was bound to. Round2 signs only the
+// session of the signer's last Round1, and only once: the Round 1 mask is
+// cleared when z is released, and any other call fails with
+// ErrSessionNotPending, as a mask reused under a second challenge leaks the
+// key share.
 func (s *Signer) Round2(sessionID int, message string, prfKey []byte, signers []int, round1Data map[int]*Round1Data) (*Round2Data, error) {
+	return s.Round2Ctx(context.Background(), sessionID, message, prfKey, signers, round1Data)
+}
+
+// Round2Ctx is Round2 that gives up with ctx.Err() once ctx is done, checked
+// before the MAC preprocessing, between it and the z computation, and before
+// z is released. A canceled Round2Ctx leaves the party as it found it, Round 1
+// state included, so the session can be retried or aborted with AbortSession.
+func (s *Signer) Round2Ctx(ctx context.Context, sessionID int, message string, prfKey []byte, signers []int, round1Data map[int]*Round1Data) (*Round2Data, error) {
+	if err := s.round2Canceled(ctx, sessionID, "start"); err != nil {
+		return nil, err
+	}
+	signers = primitives.CanonicalSignerSet(signers)
+	prfKey, err := s.roundPRFKey(sessionID, prfKey)
+	if err != nil {
+		s.logger.Warn("round 2 PRF key rejected", "party", s.share.Index, "session", sessionID, "err", err)
+		return nil, err
+	}
+	s.mu.Lock()
+	_, aborted := s.aborted[sessionID]
+	rejections := s.rejections[message]
+	overBudget := s.MaxRejectionRetries > 0 && rejections >= s.MaxRejectionRetries
+	held := s.hasPending && s.pending == sessionID && s.party.R != nil
+	if held && !aborted && !overBudget {
+		// Claim the session so no concurrent Round2 signs with the same mask.
+		s.hasPending = false
+	}
+	s.mu.Unlock()
+	if overBudget {
+		s.logger.Warn("rejection budget exceeded", "party", s.share.Index, "session", sessionID, "attempts", rejections)
+		return nil, fmt.Errorf("%w after %d attempts", ErrRejectionBudgetExceeded, rejections)
+	}
+	if aborted {
+		s.logger.Warn("round 2 on aborted session", "party", s.share.Index, "session", sessionID)
+		return nil, ErrSessionAborted
+	}
+	if !held {
+		s.logger.Warn("round 2 without round 1 state", "party", s.share.Index, "session", sessionID)
+		return nil, fmt.Errorf("%w: %d", ErrSessionNotPending, sessionID)
+	}
+	released := false
+	defer func() {
+		s.finishSession(sessionID, released)
+	}()
+
+	if err := s.share.Validate(); err != nil {
+		s.logger.Warn("round 2 invalid key share", "party", s.share.Index, "session", sessionID, "err", err)
+		return nil, err
+	}
+	share, err := s.combinedShare(signers)
+	if err != nil {
+		s.logger.Warn("round 2 invalid signer set", "party", s.share.Index, "session", sessionID, "err", err)
+		return nil, err
+	}
 	if len(round1Data) < len(signers) {
+		s.logger.Warn("round 2 missing round 1 data", "party", s.share.Index, "session", sessionID, "have", len(round1Data), "want", len(signers))
 		return nil, ErrInsufficientData
 	}
 
@@ -223,7 +529,7 @@ func (s *Signer) Round2(sessionID int, message string, prfKey []byte, signers []
 	}
 
 	// Preprocess: verify MACs and compute aggregated D
-	valid, DSum, hash := s.party.SignRound2Preprocess(
+	DSum, hash, err := s.party.VerifyRound1(
 		s.share.GroupKey.A,
 		s.share.GroupKey.BTilde,
 		D,
@@ -231,11 +537,23 @@ func (s *Signer) Round2(sessionID int, message string, prfKey []byte, signers []
 		sessionID,
 		signers,
 	)
-	if !valid {
-		return nil, ErrMACVerifyFailed
+	if errors.Is(err, sign.ErrNotFullRank) {
+		s.logger.Warn("round 2 full rank check failed", "party", s.share.Index, "session", sessionID)
+		return nil, ErrFullRankFailed
+	}
+	if err != nil {
+		s.logger.Warn("round 2 MAC verification failed", "party", s.share.Index, "session", sessionID, "err", err)
+		return nil, fmt.Errorf("%w: %w", ErrMACVerifyFailed, err)
+	}
+	if err := s.round2Canceled(ctx, sessionID, "preprocess"); err != nil {
+		return nil, err
 	}
 
-	// Compute z share
+	// Compute z share. The combined share already carries the Lagrange
+	// coefficients for this signer set, so Lambda is set to one. H and C are
+	// kept to put back if ctx is done before z is released.
+	prevH, prevC := s.party.H, s.party.C
+	s.party.SkShare, s.party.Lambda = share, montgomeryOne(s.params.R)
 	z := s.party.SignRound2(
 		s.share.GroupKey.A,
 		s.share.GroupKey.BTilde,
@@ -246,6 +564,14 @@ func (s *Signer) Round2(sessionID int, message string, prfKey []byte, signers []
 		prfKey,
 		hash,
 	)
+	s.party.SkShare, s.party.Lambda = s.share.SkShare, s.share.Lambda
+	if err := s.round2Canceled(ctx, sessionID, "z computation"); err != nil {
+		s.party.H, s.party.C = prevH, prevC
+		return nil, err
+	}
+	released = true
+	s.recordPartialSession(sessionID, message, prfKey, signers, hash)
+	s.logger.Debug("round 2 complete", "party", s.share.Index, "session", sessionID)
 
 	return &Round2Data{
 		PartyID: s.share.Index,
@@ -253,8 +579,58 @@ func (s *Signer) Round2(sessionID int, message string, prfKey []byte, signers []
 	}, nil
 }
 
+// finishSession settles the session Round2 claimed. Once z is released the
+// Round 1 mask is cleared; otherwise the session is handed back, unless a
+// Round1 for another session has started meanwhile, so it can be retried.
+func (s *Signer) finishSession(sessionID int, released bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch {
+	case released:
+		s.party.R = nil
+		s.party.D = nil
+	case !s.hasPending:
+		s.pending, s.hasPending = sessionID, true
+	}
+}
+
+// round2Canceled returns ctx.Err() if ctx is done, logging the Round 2 step
+// after which the session gave up.
+func (s *Signer) round2Canceled(ctx context.Context, sessionID int, step string) error {
+	err := ctx.Err()
+	if err != nil {
+		s.logger.Warn("round 2 canceled", "party", s.share.Index, "session", sessionID, "after", step, "err", err)
+	}
+	return err
+}
+
+// ComputeDSum returns the sum of the round 1 D matrices, keyed by party, that
+// Round2 signs against. A coordinator aggregating D itself can compare its
+// result with this one.
+func (s *Signer) ComputeDSum(D map[int]structs.Matrix[ring.Poly]) structs.Matrix[ring.Poly] {
+	return sign.SumD(s.params.R, D)
+}
+
+// AbortSession abandons a session between the rounds. The Round 1 mask held
+// for it is cleared, and Round2 for sessionID fails with ErrSessionAborted from
+// then on, so no z share is ever released for the abandoned commitment.
+func (s *Signer) AbortSession(sessionID int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.aborted[sessionID] = struct{}{}
+	if s.hasPending && s.pending == sessionID {
+		s.party.R = nil
+		s.party.D = nil
+		s.hasPending = false
+	}
+	s.logger.Info("session aborted", "party", s.share.Index, "session", sessionID)
+}
+
 // Finalize aggregates z shares into the final signature.
 // Any party can call this with the collected Round 2 data.
+// A signature exceeding NormBound is rejected with ErrSignatureRejected and
+// counts against the MaxRejectionRetries budget of the message this signer
+// last ran Round2 on; an accepted one resets that message's count.
 func (s *Signer) Finalize(round2Data map[int]*Round2Data) (*Signature, error) {
 	if len(round2Data) == 0 {
 		return nil, ErrInsufficientData
@@ -267,6 +643,16 @@ func (s *Signer) Finalize(round2Data map[int]*Round2Data) (*Signature, error) {
 	}
 
 	c, zSum, delta := s.party.SignFinalize(z, s.share.GroupKey.A, s.share.GroupKey.BTilde)
+	if s.NormBound != nil {
+		if norm := sign.SignatureNormSquared(s.params.R, s.params.RNu, zSum, delta); norm.Cmp(s.NormBound) > 0 {
+			attempt := s.recordRejection(true)
+			s.logger.Warn("signature rejected by norm bound", "party", s.share.Index, "attempt", attempt)
+			return nil, fmt.Errorf("%w (attempt %d)", ErrSignatureRejected, attempt)
+		}
+	}
+	s.recordRejection(false)
+
+	s.logger.Info("signature finalized", "party", s.share.Index, "shares", len(round2Data))
 	return &Signature{
 		C:     c,
 		Z:     zSum,
@@ -274,6 +660,39 @@ func (s *Signer) Finalize(round2Data map[int]*Round2Data) (*Signature, error) {
 	}, nil
 }
 
+// maxRejectedMessages bounds the messages a Signer tracks rejection counts
+// for. The counts are cleared when it fills.
+const maxRejectedMessages = 64
+
+// recordRejection updates the rejection count of the message of the last
+// Round2, incrementing it if rejected and clearing it otherwise, and returns
+// the new count.
+func (s *Signer) recordRejection(rejected bool) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var message string
+	if s.partial != nil {
+		message = s.partial.message
+	}
+	if !rejected {
+		delete(s.rejections, message)
+		return 0
+	}
+	if _, ok := s.rejections[message]; !ok && len(s.rejections) >= maxRejectedMessages {
+		clear(s.rejections)
+	}
+	s.rejections[message]++
+	return s.rejections[message]
+}
+
+// ResetRejections clears the rejection count of every message, so Round2
+// again starts attempts at messages whose budget was exhausted.
+func (s *Signer) ResetRejections() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	clear(s.rejections)
+}
+
 // Verify checks if a signature is valid for the given message.
 func Verify(groupKey *GroupKey, message string, sig *Signature) bool {
 	if groupKey == nil || sig == nil {
@@ -291,3 +710,116 @@ func Verify(groupKey *GroupKey, message string, sig *Signature) bool {
 		sig.Delta,
 	)
 }
+
+// VerifyAny verifies sig against each group key in turn, for a verifier that
+// does not know which key signed, such as during an epoch transition. It
+// returns the index of the first key under which sig verifies, or -1 and false.
+func VerifyAny(groupKeys []*GroupKey, message string, sig *Signature) (int, bool) {
+	for i, groupKey := range groupKeys {
+		if Verify(groupKey, message, sig) {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+// PreparedGroupKey is a group key with its verification precomputation done.
+// Build one with PrepareGroupKey and reuse it across many signatures.
+type PreparedGroupKey struct {
+	GroupKey *GroupKey
+	key      *sign.PreparedKey
+}
+
+// PrepareGroupKey precomputes the NTT-domain public key and hash prefix used by Verify.
+func PrepareGroupKey(groupKey *GroupKey) *PreparedGroupKey {
+	if groupKey == nil {
+		return nil
+	}
+	return &PreparedGroupKey{
+		GroupKey: groupKey,
+		key:      sign.PrepareKey(groupKey.Params.R, groupKey.Params.RXi, groupKey.A, groupKey.BTilde),
+	}
+}
+
+// VerifyPrepared verifies a threshold signature against a prepared group key.
+// It accepts exactly the signatures Verify accepts.
+func VerifyPrepared(pgk *PreparedGroupKey, message string, sig *Signature) bool {
+	if pgk == nil || sig == nil {
+		return false
+	}
+	return sign.VerifyPrepared(
+		pgk.GroupKey.Params.R,
+		pgk.GroupKey.Params.RNu,
+		pgk.key,
+		sig.Z,
+		message,
+		sig.C,
+		sig.Delta,
+	)
+}
+
+// VerifyItem is a message and signature for VerifyBatch.
+type VerifyItem struct {
+	Message   string
+	Signature *Signature
+}
+
+// VerifyBatch verifies every item against groupKey, preparing the key once
+// for the whole batch. results[i] is what Verify reports for items[i].
+func VerifyBatch(groupKey *GroupKey, items []VerifyItem) []bool {
+	results := make([]bool, len(items))
+	if groupKey == nil || len(items) == 0 {
+		return results
+	}
+	pgk := PrepareGroupKey(groupKey)
+	for i, item := range items {
+		results[i] = VerifyPrepared(pgk, item.Message, item.Signature)
+	}
+	return results
+}
+
+// VerifyResult reports the outcome of one VerifyBatchStream item.
+type VerifyResult struct {
+	Index int   // Position of the item in the batch
+	OK    bool  // Whether the signature verified
+	Err   error // ctx.Err() if ctx was done before the item was verified
+}
+
+// VerifyBatchStream is VerifyBatch on a pool of workers that sends each
+// result as soon as it is known, so a consumer can act on early results. Every
+// index is reported exactly once, in completion order, and the channel is
+// closed after the last. Once ctx is done, the items not yet verified are
+// reported with its error. The channel holds every result, so workers never
+// block on a consumer that stops reading.
+func VerifyBatchStream(ctx context.Context, groupKey *GroupKey, items []VerifyItem) <-chan VerifyResult {
+	results := make(chan VerifyResult, len(items))
+	var pgk *PreparedGroupKey
+	if len(items) > 0 {
+		pgk = PrepareGroupKey(groupKey)
+	}
+
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(runtime.GOMAXPROCS(0), len(items)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				if err := ctx.Err(); err != nil {
+					results <- VerifyResult{Index: i, Err: err}
+					continue
+				}
+				results <- VerifyResult{Index: i, OK: VerifyPrepared(pgk, items[i].Message, items[i].Signature)}
+			}
+		}()
+	}
+	go func() {
+		for i := range items {
+			next <- i
+		}
+		close(next)
+		wg.Wait()
+		close(results)
+	}()
+	return results
+}
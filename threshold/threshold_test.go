@@ -4,7 +4,20 @@
 package threshold
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
 	"testing"
+
+	"github.com/luxfi/ringtail/primitives"
+	"github.com/luxfi/ringtail/sign"
+	"github.com/luxfi/ringtail/utils"
+
+	"github.com/luxfi/lattice/v7/ring"
+	"github.com/luxfi/lattice/v7/utils/structs"
 )
 
 func TestGenerateKeys(t *testing.T) {
@@ -36,6 +49,46 @@ func TestGenerateKeys(t *testing.T) {
 	}
 }
 
+func TestDeriveSeeds(t *testing.T) {
+	tests := []struct {
+		name      string
+		weights   []int
+		threshold int
+	}{
+		{name: "2 of 3", weights: []int{1, 1, 1}, threshold: 2},
+		{name: "weighted", weights: []int{3, 1, 1, 2}, threshold: 4},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dealerKey := bytes.Repeat([]byte{byte(0xa0 + i)}, sign.KeySize)
+			shares, _, err := GenerateWeightedKeys(tt.weights, tt.threshold, bytes.NewReader(dealerKey))
+			if err != nil {
+				t.Fatalf("GenerateWeightedKeys failed: %v", err)
+			}
+
+			derived := sign.DeriveSeeds(dealerKey, len(tt.weights))
+			for _, share := range shares {
+				if len(share.Seeds) != len(derived) {
+					t.Fatalf("share %d has seeds for %d parties, want %d", share.Index, len(share.Seeds), len(derived))
+				}
+				for j, row := range derived {
+					for k, seed := range row {
+						if !bytes.Equal(share.Seeds[j][k], seed) {
+							t.Errorf("share %d: seed [%d][%d] does not match the dealer key", share.Index, j, k)
+						}
+					}
+				}
+			}
+
+			other := sign.DeriveSeeds(bytes.Repeat([]byte{0xff}, sign.KeySize), len(tt.weights))
+			if bytes.Equal(other[0][1], shares[0].Seeds[0][1]) {
+				t.Error("seeds derived from another dealer key match")
+			}
+		})
+	}
+}
+
 func TestThresholdSigningFlow(t *testing.T) {
 	// Generate 2-of-3 threshold keys
 	shares, groupKey, err := GenerateKeys(2, 3, nil)
@@ -80,6 +133,9 @@ func TestThresholdSigningFlow(t *testing.T) {
 		t.Fatalf("Finalize failed: %v", err)
 	}
 	t.Logf("Signature: C degree=%d, Z size=%d, Delta size=%d", sig.C.N(), len(sig.Z), len(sig.Delta))
+	if err := sign.CheckChallenge(groupKey.Params.R, sig.C, sign.Kappa); err != nil {
+		t.Errorf("signature has a trivial challenge: %v", err)
+	}
 
 	// Verify
 	valid := Verify(groupKey, message, sig)
@@ -87,6 +143,19 @@ func TestThresholdSigningFlow(t *testing.T) {
 		t.Error("signature verification failed")
 	}
 	t.Log("✓ Signature verified successfully")
+
+	encoded, err := sig.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary failed: %v", err)
+	}
+	var decoded Signature
+	if err := decoded.UnmarshalBinary(encoded); err != nil {
+		t.Fatalf("UnmarshalBinary failed: %v", err)
+	}
+	if !Verify(groupKey, message, &decoded) {
+		t.Error("decoded signature does not verify")
+	}
+	t.Logf("Signature: %d bytes", len(encoded))
 }
 
 func TestThresholdWrongMessage(t *testing.T) {
@@ -129,6 +198,103 @@ func TestThresholdWrongMessage(t *testing.T) {
 	}
 }
 
+func TestVerifyRejectsNonCanonical(t *testing.T) {
+	shares, groupKey, err := GenerateKeys(2, 3, nil)
+	if err != nil {
+		t.Fatalf("GenerateKeys failed: %v", err)
+	}
+	message := "canonical"
+	sig, err := signSession(newSigners(shares), []int{0, 1}, 1, message)
+	if err != nil {
+		t.Fatalf("signing failed: %v", err)
+	}
+	if !Verify(groupKey, message, sig) {
+		t.Fatal("honest signature did not verify")
+	}
+
+	copySig := func() *Signature {
+		c := &Signature{C: *sig.C.CopyNew(), Z: make(structs.Vector[ring.Poly], len(sig.Z)), Delta: make(structs.Vector[ring.Poly], len(sig.Delta))}
+		for i := range sig.Z {
+			c.Z[i] = *sig.Z[i].CopyNew()
+		}
+		for i := range sig.Delta {
+			c.Delta[i] = *sig.Delta[i].CopyNew()
+		}
+		return c
+	}
+
+	// Each maul leaves the values the verification equation reads unchanged
+	// up to reduction, so only the encoding differs from the honest signature.
+	tests := []struct {
+		name string
+		maul func(s *Signature)
+	}{
+		{name: "z coefficient plus q", maul: func(s *Signature) { s.Z[0].Coeffs[0][0] += sign.Q }},
+		{name: "c coefficient plus q", maul: func(s *Signature) { s.C.Coeffs[0][5] += sign.Q }},
+		{name: "Delta coefficient plus q_nu", maul: func(s *Signature) { s.Delta[1].Coeffs[0][3] += sign.QNu }},
+		{name: "extra z entry", maul: func(s *Signature) { s.Z = append(s.Z, groupKey.Params.R.NewPoly()) }},
+		{name: "extra Delta entry", maul: func(s *Signature) { s.Delta = append(s.Delta, groupKey.Params.RNu.NewPoly()) }},
+	}
+
+	pgk := PrepareGroupKey(groupKey)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mauled := copySig()
+			tt.maul(mauled)
+			if Verify(groupKey, message, mauled) {
+				t.Error("Verify accepted a non-canonical signature")
+			}
+			if VerifyPrepared(pgk, message, mauled) {
+				t.Error("VerifyPrepared accepted a non-canonical signature")
+			}
+		})
+	}
+
+	if !Verify(groupKey, message, sig) {
+		t.Error("mauling a copy changed the honest signature")
+	}
+}
+
+func TestVerifyAny(t *testing.T) {
+	_, oldKey, err := GenerateKeys(2, 3, nil)
+	if err != nil {
+		t.Fatalf("GenerateKeys failed: %v", err)
+	}
+	shares, newKey, err := GenerateKeys(2, 3, nil)
+	if err != nil {
+		t.Fatalf("GenerateKeys failed: %v", err)
+	}
+	message := "epoch transition"
+	sig, err := signSession(newSigners(shares), []int{1, 2}, 1, message)
+	if err != nil {
+		t.Fatalf("signing failed: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		keys      []*GroupKey
+		message   string
+		wantIndex int
+		wantOK    bool
+	}{
+		{name: "second key", keys: []*GroupKey{oldKey, newKey}, message: message, wantIndex: 1, wantOK: true},
+		{name: "first key", keys: []*GroupKey{newKey, oldKey}, message: message, wantIndex: 0, wantOK: true},
+		{name: "no matching key", keys: []*GroupKey{oldKey}, message: message, wantIndex: -1},
+		{name: "wrong message", keys: []*GroupKey{oldKey, newKey}, message: "other", wantIndex: -1},
+		{name: "nil key skipped", keys: []*GroupKey{nil, newKey}, message: message, wantIndex: 1, wantOK: true},
+		{name: "no keys", keys: nil, message: message, wantIndex: -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			index, ok := VerifyAny(tt.keys, tt.message, sig)
+			if index != tt.wantIndex || ok != tt.wantOK {
+				t.Errorf("VerifyAny = (%d, %v), want (%d, %v)", index, ok, tt.wantIndex, tt.wantOK)
+			}
+		})
+	}
+}
+
 func TestInvalidThreshold(t *testing.T) {
 	// Threshold >= total
 	_, _, err := GenerateKeys(3, 3, nil)
@@ -148,3 +314,761 @@ func TestInvalidThreshold(t *testing.T) {
 		t.Errorf("expected ErrInvalidPartyCount, got %v", err)
 	}
 }
+
+func TestRejectionBudget(t *testing.T) {
+	shares, groupKey, err := GenerateKeys(2, 3, nil)
+	if err != nil {
+		t.Fatalf("GenerateKeys failed: %v", err)
+	}
+	signers := newSigners(shares)
+	signerIDs := []int{0, 1, 2}
+	message := "rejection budget"
+
+	// The default bound is the one Verify enforces, so an honest signature
+	// is accepted on the first attempt.
+	sig, err := signSession(signers, signerIDs, 1, message)
+	if err != nil {
+		t.Fatalf("signing with the default bound failed: %v", err)
+	}
+	if !Verify(groupKey, message, sig) {
+		t.Fatal("signature verification failed")
+	}
+	if len(signers[0].rejections) != 0 {
+		t.Errorf("expected no rejections, got %v", signers[0].rejections)
+	}
+
+	// A bound no signature can meet exhausts the budget.
+	normBound := signers[0].NormBound
+	signers[0].NormBound = big.NewInt(1)
+	signers[0].MaxRejectionRetries = 2
+	rejected := 0
+	for sessionID := 2; ; sessionID++ {
+		_, err := signSession(signers, signerIDs, sessionID, message)
+		if errors.Is(err, ErrRejectionBudgetExceeded) {
+			if !strings.Contains(err.Error(), "2 attempts") {
+				t.Errorf("error %q does not report the attempts made", err)
+			}
+			break
+		}
+		if !errors.Is(err, ErrSignatureRejected) {
+			t.Fatalf("expected ErrSignatureRejected, got %v", err)
+		}
+		rejected++
+		if rejected > signers[0].MaxRejectionRetries {
+			t.Fatal("Round2 did not enforce the rejection budget")
+		}
+	}
+	if rejected != 2 {
+		t.Errorf("expected 2 rejected attempts, got %d", rejected)
+	}
+
+	// The exhausted budget is that of one message: the signer still signs
+	// others, and ResetRejections lets it retry the first.
+	signers[0].NormBound = normBound
+	other := "another message"
+	sig, err = signSession(signers, signerIDs, 100, other)
+	if err != nil {
+		t.Fatalf("signing another message after the budget was exhausted failed: %v", err)
+	}
+	if !Verify(groupKey, other, sig) {
+		t.Error("signature of another message failed verification")
+	}
+	if _, err := signSession(signers, signerIDs, 101, message); !errors.Is(err, ErrRejectionBudgetExceeded) {
+		t.Fatalf("expected ErrRejectionBudgetExceeded for the exhausted message, got %v", err)
+	}
+	signers[0].ResetRejections()
+	sig, err = signSession(signers, signerIDs, 102, message)
+	if err != nil {
+		t.Fatalf("signing after ResetRejections failed: %v", err)
+	}
+	if !Verify(groupKey, message, sig) {
+		t.Error("signature after ResetRejections failed verification")
+	}
+}
+
+func newSigners(shares []*KeyShare) []*Signer {
+	signers := make([]*Signer, len(shares))
+	for i, share := range shares {
+		signers[i] = NewSigner(share)
+	}
+	return signers
+}
+
+// signSession runs both rounds for the given signers (indexed by party ID)
+// and finalizes with the first of them.
+func signSession(signers []*Signer, signerIDs []int, sessionID int, message string) (*Signature, error) {
+	prfKey := []byte("test-prf-key-32-bytes-long!!!!!!")
+
+	round1Data := make(map[int]*Round1Data)
+	for _, id := range signerIDs {
+		data := signers[id].Round1(sessionID, prfKey, signerIDs)
+		round1Data[data.PartyID] = data
+	}
+
+	round2Data := make(map[int]*Round2Data)
+	for _, id := range signerIDs {
+		data, err := signers[id].Round2(sessionID, message, prfKey, signerIDs, round1Data)
+		if err != nil {
+			return nil, err
+		}
+		round2Data[data.PartyID] = data
+	}
+
+	return signers[signerIDs[0]].Finalize(round2Data)
+}
+
+func TestPresetsRoundTrip(t *testing.T) {
+	for _, preset := range sign.Presets() {
+		t.Run(preset.Name, func(t *testing.T) {
+			params, err := NewParamsFromPreset(&preset)
+			if err != nil {
+				t.Fatalf("NewParamsFromPreset failed: %v", err)
+			}
+
+			shares, groupKey, err := GenerateKeysWithParams(params, 2, 3, nil)
+			if err != nil {
+				t.Fatalf("GenerateKeysWithParams failed: %v", err)
+			}
+			if groupKey.Params != params {
+				t.Fatal("group key does not use the preset's params")
+			}
+			if got := groupKey.Params.R.N(); got != 1<<preset.LogN {
+				t.Fatalf("keygen ring has degree %d, want %d", got, 1<<preset.LogN)
+			}
+
+			message := "preset " + preset.Name
+			sig, err := signSession(newSigners(shares), []int{0, 1, 2}, 1, message)
+			if err != nil {
+				t.Fatalf("signing failed: %v", err)
+			}
+			if !Verify(groupKey, message, sig) {
+				t.Fatal("signature verification failed")
+			}
+		})
+	}
+}
+
+func TestNewParamsBadRoundingModulus(t *testing.T) {
+	tests := []struct {
+		name string
+		edit func(p *sign.Preset)
+	}{
+		{name: "QXi not a power of 2", edit: func(p *sign.Preset) { p.QXi = 3 << 16 }},
+		{name: "QXi zero", edit: func(p *sign.Preset) { p.QXi = 0 }},
+		{name: "QNu not a power of 2", edit: func(p *sign.Preset) { p.QNu++ }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			preset, err := sign.NewParamsPreset(DefaultPreset)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tt.edit(preset)
+			params, err := NewParamsFromPreset(preset)
+			if !errors.Is(err, sign.ErrInvalidRoundingModulus) {
+				t.Fatalf("expected ErrInvalidRoundingModulus, got %v", err)
+			}
+			if params != nil {
+				t.Error("expected nil params on error")
+			}
+		})
+	}
+}
+
+func TestLambdaStandard(t *testing.T) {
+	shares, groupKey, err := GenerateKeys(2, 3, nil)
+	if err != nil {
+		t.Fatalf("GenerateKeys failed: %v", err)
+	}
+	r := groupKey.Params.R
+
+	T := []int{0, 1, 2}
+	want := primitives.ComputeLagrangeCoefficients(r, T, r.Modulus())
+	for _, share := range shares {
+		standard := share.LambdaStandard()
+		if !r.Equal(standard, want[share.Index]) {
+			t.Errorf("party %d: LambdaStandard does not match the Lagrange coefficient", share.Index)
+		}
+
+		// Converting back must reproduce the stored representation.
+		internal := *standard.CopyNew()
+		r.NTT(internal, internal)
+		r.MForm(internal, internal)
+		if !r.Equal(internal, share.Lambda) {
+			t.Errorf("party %d: round trip to NTT/Montgomery form differs from Lambda", share.Index)
+		}
+	}
+}
+
+func TestKeyShareValidate(t *testing.T) {
+	shares, _, err := GenerateWeightedKeys([]int{2, 1, 1}, 2, nil)
+	if err != nil {
+		t.Fatalf("GenerateWeightedKeys failed: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		tamper  func(ks *KeyShare)
+		wantErr bool
+	}{
+		{name: "valid", tamper: func(ks *KeyShare) {}},
+		{name: "short share", tamper: func(ks *KeyShare) { ks.SkShare = ks.SkShare[:sign.N-1] }, wantErr: true},
+		{name: "long share", tamper: func(ks *KeyShare) { ks.SkShare = append(ks.SkShare[:sign.N:sign.N], ks.SkShare[0]) }, wantErr: true},
+		{name: "short slot share", tamper: func(ks *KeyShare) {
+			ks.SlotShares = []structs.Vector[ring.Poly]{ks.SlotShares[0], ks.SlotShares[1][:1]}
+		}, wantErr: true},
+		{name: "missing slot", tamper: func(ks *KeyShare) { ks.SlotShares = ks.SlotShares[:1] }, wantErr: true},
+		{name: "index out of range", tamper: func(ks *KeyShare) { ks.Index = 3 }, wantErr: true},
+		{name: "no group key", tamper: func(ks *KeyShare) { ks.GroupKey = nil }, wantErr: true},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			share := *shares[0]
+			tt.tamper(&share)
+			err := share.Validate()
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatalf("Validate failed on a valid share: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrInvalidKeyShare) {
+				t.Fatalf("expected ErrInvalidKeyShare, got %v", err)
+			}
+			if share.GroupKey == nil || share.Index != 0 {
+				return
+			}
+
+			// The mismatch is caught before any z share is computed.
+			signers := newSigners(shares)
+			signers[0] = NewSigner(&share)
+			signerIDs := []int{0, 1}
+			prfKey := []byte("test-prf-key-32-bytes-long!!!!!!")
+			round1Data := make(map[int]*Round1Data)
+			for _, id := range signerIDs {
+				round1Data[id] = signers[id].Round1(i+1, prfKey, signerIDs)
+			}
+			if _, err := signers[0].Round2(i+1, "message", prfKey, signerIDs, round1Data); !errors.Is(err, ErrInvalidKeyShare) {
+				t.Errorf("Round2: expected ErrInvalidKeyShare, got %v", err)
+			}
+			if err := NewSessionManager().Open(i+1, 0, &share, signerIDs); !errors.Is(err, ErrInvalidKeyShare) {
+				t.Errorf("Open: expected ErrInvalidKeyShare, got %v", err)
+			}
+		})
+	}
+}
+
+func TestRound2RequiresEveryMAC(t *testing.T) {
+	tests := []struct {
+		name   string
+		tamper func(macs map[int][]byte)
+	}{
+		{name: "missing MAC", tamper: func(macs map[int][]byte) { delete(macs, 0) }},
+		{name: "empty MAC", tamper: func(macs map[int][]byte) { macs[0] = nil }},
+		{name: "corrupted MAC", tamper: func(macs map[int][]byte) { macs[0][0] ^= 0x01 }},
+	}
+
+	shares, _, err := GenerateKeys(2, 3, nil)
+	if err != nil {
+		t.Fatalf("GenerateKeys failed: %v", err)
+	}
+	prfKey := []byte("test-prf-key-32-bytes-long!!!!!!")
+	signerIDs := []int{0, 1, 2}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signers := newSigners(shares)
+			sessionID := i + 1
+
+			round1Data := make(map[int]*Round1Data)
+			for _, id := range signerIDs {
+				data := signers[id].Round1(sessionID, prfKey, signerIDs)
+				round1Data[data.PartyID] = data
+			}
+			tt.tamper(round1Data[1].MACs)
+
+			_, err := signers[0].Round2(sessionID, "message", prfKey, signerIDs, round1Data)
+			if !errors.Is(err, ErrMACVerifyFailed) || !errors.Is(err, sign.ErrInvalidMAC) {
+				t.Fatalf("expected ErrMACVerifyFailed wrapping sign.ErrInvalidMAC, got %v", err)
+			}
+			if !strings.Contains(err.Error(), "from party 1 to party 0") {
+				t.Errorf("error %q does not identify the pair", err)
+			}
+
+			// Party 2 received an intact MAC from party 1 and can proceed.
+			if _, err := signers[2].Round2(sessionID, "message", prfKey, signerIDs, round1Data); err != nil {
+				t.Errorf("unaffected party failed Round2: %v", err)
+			}
+		})
+	}
+}
+
+func TestComputeDSum(t *testing.T) {
+	shares, _, err := GenerateKeys(2, 3, nil)
+	if err != nil {
+		t.Fatalf("GenerateKeys failed: %v", err)
+	}
+	signers := newSigners(shares)
+	prfKey := []byte("test-prf-key-32-bytes-long!!!!!!")
+	signerIDs := []int{0, 2}
+	sessionID := 1
+
+	round1Data := make(map[int]*Round1Data)
+	D := make(map[int]structs.Matrix[ring.Poly])
+	MACs := make(map[int]map[int][]byte)
+	for _, id := range signerIDs {
+		data := signers[id].Round1(sessionID, prfKey, signerIDs)
+		round1Data[id] = data
+		D[id], MACs[id] = data.D, data.MACs
+	}
+
+	gk := shares[0].GroupKey
+	internal, _, err := signers[0].party.VerifyRound1(gk.A, gk.BTilde, D, MACs, sessionID, signerIDs)
+	if err != nil {
+		t.Fatalf("VerifyRound1 failed: %v", err)
+	}
+	external := signers[2].ComputeDSum(D)
+
+	// The coordinator's own sum, added in the opposite order.
+	r := gk.Params.R
+	manual := utils.InitializeMatrix(r, sign.M, sign.Dbar+1)
+	for i := len(signerIDs) - 1; i >= 0; i-- {
+		utils.MatrixAdd(r, D[signerIDs[i]], manual, manual)
+	}
+
+	if len(external) != len(internal) || len(manual) != len(internal) {
+		t.Fatalf("DSum has %d rows, want %d", len(external), len(internal))
+	}
+	for i := range internal {
+		if !utils.VectorEqual(r, external[i], internal[i]) {
+			t.Errorf("row %d of ComputeDSum differs from Round 2 preprocessing", i)
+		}
+		if !utils.VectorEqual(r, manual[i], internal[i]) {
+			t.Errorf("row %d of the coordinator's sum differs from Round 2 preprocessing", i)
+		}
+	}
+
+	if _, err := signers[0].Round2(sessionID, "dsum", prfKey, signerIDs, round1Data); err != nil {
+		t.Errorf("Round2 failed after ComputeDSum: %v", err)
+	}
+}
+
+func TestVerifyPrepared(t *testing.T) {
+	shares, groupKey, err := GenerateKeys(2, 3, nil)
+	if err != nil {
+		t.Fatalf("GenerateKeys failed: %v", err)
+	}
+	message := "prepared key"
+	sig, err := signSession(newSigners(shares), []int{0, 1, 2}, 1, message)
+	if err != nil {
+		t.Fatalf("signing failed: %v", err)
+	}
+
+	tampered := &Signature{C: sig.C, Z: append(sig.Z[:0:0], sig.Z...), Delta: sig.Delta}
+	tampered.Z[0] = *sig.Z[0].CopyNew()
+	tampered.Z[0].Coeffs[0][0] ^= 1
+
+	pgk := PrepareGroupKey(groupKey)
+	tests := []struct {
+		name    string
+		message string
+		sig     *Signature
+	}{
+		{name: "valid", message: message, sig: sig},
+		{name: "wrong message", message: "other message", sig: sig},
+		{name: "tampered z", message: message, sig: tampered},
+		{name: "nil signature", message: message, sig: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := Verify(groupKey, tt.message, tt.sig)
+			// Verify twice to check the prepared key is not consumed.
+			for i := 0; i < 2; i++ {
+				if got := VerifyPrepared(pgk, tt.message, tt.sig); got != want {
+					t.Fatalf("VerifyPrepared = %v, Verify = %v", got, want)
+				}
+			}
+		})
+	}
+	if !VerifyPrepared(pgk, message, sig) {
+		t.Error("valid signature rejected")
+	}
+}
+
+func BenchmarkVerify(b *testing.B) {
+	shares, groupKey, err := GenerateKeys(2, 3, nil)
+	if err != nil {
+		b.Fatalf("GenerateKeys failed: %v", err)
+	}
+	message := "benchmark"
+	sig, err := signSession(newSigners(shares), []int{0, 1, 2}, 1, message)
+	if err != nil {
+		b.Fatalf("signing failed: %v", err)
+	}
+
+	b.Run("Verify", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			Verify(groupKey, message, sig)
+		}
+	})
+	b.Run("VerifyPrepared", func(b *testing.B) {
+		pgk := PrepareGroupKey(groupKey)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			VerifyPrepared(pgk, message, sig)
+		}
+	})
+}
+
+func TestVerifyBatch(t *testing.T) {
+	shares, groupKey, err := GenerateKeys(2, 3, nil)
+	if err != nil {
+		t.Fatalf("GenerateKeys failed: %v", err)
+	}
+	signers := newSigners(shares)
+	var items []VerifyItem
+	for i := 0; i < 3; i++ {
+		message := fmt.Sprintf("batch %d", i)
+		sig, err := signSession(signers, []int{0, 1}, i+1, message)
+		if err != nil {
+			t.Fatalf("signing failed: %v", err)
+		}
+		items = append(items, VerifyItem{Message: message, Signature: sig})
+	}
+	items = append(items,
+		VerifyItem{Message: "other message", Signature: items[0].Signature},
+		VerifyItem{Message: items[1].Message, Signature: items[2].Signature},
+		VerifyItem{Message: items[2].Message},
+	)
+
+	got := VerifyBatch(groupKey, items)
+	if len(got) != len(items) {
+		t.Fatalf("got %d results for %d items", len(got), len(items))
+	}
+	for i, item := range items {
+		if want := Verify(groupKey, item.Message, item.Signature); got[i] != want {
+			t.Errorf("item %d: VerifyBatch = %v, Verify = %v", i, got[i], want)
+		}
+	}
+	if !got[0] || got[3] {
+		t.Errorf("unexpected results %v", got)
+	}
+	for i, ok := range VerifyBatch(nil, items) {
+		if ok {
+			t.Errorf("item %d verified under a nil group key", i)
+		}
+	}
+}
+
+func TestVerifyBatchStream(t *testing.T) {
+	shares, groupKey, err := GenerateKeys(2, 3, nil)
+	if err != nil {
+		t.Fatalf("GenerateKeys failed: %v", err)
+	}
+	message := "stream"
+	sig, err := signSession(newSigners(shares), []int{1, 2}, 1, message)
+	if err != nil {
+		t.Fatalf("signing failed: %v", err)
+	}
+	items := make([]VerifyItem, 8)
+	for i := range items {
+		items[i] = VerifyItem{Message: message, Signature: sig}
+		if i%3 == 0 {
+			items[i].Message = "other message"
+		}
+	}
+
+	collect := func(ctx context.Context) map[int]VerifyResult {
+		t.Helper()
+		seen := make(map[int]VerifyResult)
+		for result := range VerifyBatchStream(ctx, groupKey, items) {
+			if _, ok := seen[result.Index]; ok {
+				t.Errorf("index %d reported twice", result.Index)
+			}
+			seen[result.Index] = result
+		}
+		if len(seen) != len(items) {
+			t.Errorf("got %d results for %d items", len(seen), len(items))
+		}
+		return seen
+	}
+
+	for i, result := range collect(context.Background()) {
+		if result.Err != nil {
+			t.Errorf("item %d: %v", i, result.Err)
+		}
+		if want := i%3 != 0; result.OK != want {
+			t.Errorf("item %d: OK = %v, want %v", i, result.OK, want)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for i, result := range collect(ctx) {
+		if !errors.Is(result.Err, context.Canceled) || result.OK {
+			t.Errorf("item %d after cancel: %+v", i, result)
+		}
+	}
+
+	if _, ok := <-VerifyBatchStream(context.Background(), groupKey, nil); ok {
+		t.Error("empty batch produced a result")
+	}
+}
+
+// cancelAfter is a context that reports itself canceled once Err has been
+// called more than checks times, to cancel Round2Ctx at a given step.
+type cancelAfter struct {
+	context.Context
+	checks int
+}
+
+func (c *cancelAfter) Err() error {
+	if c.checks == 0 {
+		return context.Canceled
+	}
+	c.checks--
+	return nil
+}
+
+func TestRound2Ctx(t *testing.T) {
+	shares, groupKey, err := GenerateKeys(2, 3, nil)
+	if err != nil {
+		t.Fatalf("GenerateKeys failed: %v", err)
+	}
+	prfKey := []byte("test-prf-key-32-bytes-long!!!!!!")
+	message := "deadline"
+	signerIDs := []int{0, 1}
+
+	tests := []struct {
+		name   string
+		checks int
+	}{
+		{name: "before preprocess", checks: 0},
+		{name: "between preprocess and z", checks: 1},
+		{name: "after z", checks: 2},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signers := newSigners(shares)
+			sessionID := i + 1
+			round1Data := make(map[int]*Round1Data)
+			for _, id := range signerIDs {
+				round1Data[id] = signers[id].Round1(sessionID, prfKey, signerIDs)
+			}
+
+			canceled := signers[0]
+			h, c := canceled.party.H, canceled.party.C
+			data, err := canceled.Round2Ctx(&cancelAfter{Context: context.Background(), checks: tt.checks}, sessionID, message, prfKey, signerIDs, round1Data)
+			if !errors.Is(err, context.Canceled) || data != nil {
+				t.Fatalf("got %v and %v, want context.Canceled", data, err)
+			}
+			if &canceled.party.SkShare[0] != &shares[0].SkShare[0] || !canceled.params.R.Equal(canceled.party.Lambda, shares[0].Lambda) {
+				t.Error("canceled Round2Ctx left the combined share in the party")
+			}
+			if len(canceled.party.H) != len(h) || len(canceled.party.C.Coeffs) != len(c.Coeffs) {
+				t.Error("canceled Round2Ctx left its challenge in the party")
+			}
+
+			round2Data := make(map[int]*Round2Data)
+			for _, id := range signerIDs {
+				data, err := signers[id].Round2Ctx(context.Background(), sessionID, message, prfKey, signerIDs, round1Data)
+				if err != nil {
+					t.Fatalf("Round2Ctx(%d) after cancellation failed: %v", id, err)
+				}
+				round2Data[id] = data
+			}
+			sig, err := signers[0].Finalize(round2Data)
+			if err != nil {
+				t.Fatalf("Finalize failed: %v", err)
+			}
+			if !Verify(groupKey, message, sig) {
+				t.Error("signature from the retried session does not verify")
+			}
+		})
+	}
+}
+
+func BenchmarkVerifyBatch(b *testing.B) {
+	shares, groupKey, err := GenerateKeys(2, 3, nil)
+	if err != nil {
+		b.Fatalf("GenerateKeys failed: %v", err)
+	}
+	message := "benchmark"
+	sig, err := signSession(newSigners(shares), []int{0, 1, 2}, 1, message)
+	if err != nil {
+		b.Fatalf("signing failed: %v", err)
+	}
+	items := make([]VerifyItem, 64)
+	for i := range items {
+		items[i] = VerifyItem{Message: message, Signature: sig}
+	}
+
+	b.Run("Loop", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, item := range items {
+				Verify(groupKey, item.Message, item.Signature)
+			}
+		}
+	})
+	b.Run("VerifyBatch", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			VerifyBatch(groupKey, items)
+		}
+	})
+}
+
+func TestAbortSession(t *testing.T) {
+	shares, groupKey, err := GenerateKeys(2, 3, nil)
+	if err != nil {
+		t.Fatalf("GenerateKeys failed: %v", err)
+	}
+	signers := newSigners(shares)
+	prfKey := []byte("test-prf-key-32-bytes-long!!!!!!")
+	signerIDs := []int{0, 1, 2}
+	sessionID := 1
+
+	round1Data := make(map[int]*Round1Data)
+	for _, id := range signerIDs {
+		data := signers[id].Round1(sessionID, prfKey, signerIDs)
+		round1Data[data.PartyID] = data
+	}
+
+	signers[0].AbortSession(sessionID)
+	if signers[0].party.R != nil || signers[0].party.D != nil {
+		t.Error("AbortSession left Round 1 state behind")
+	}
+	if _, err := signers[0].Round2(sessionID, "message", prfKey, signerIDs, round1Data); !errors.Is(err, ErrSessionAborted) {
+		t.Fatalf("expected ErrSessionAborted, got %v", err)
+	}
+
+	// The cleared mask must not sign another session either.
+	if _, err := signers[0].Round2(sessionID+1, "message", prfKey, signerIDs, round1Data); !errors.Is(err, ErrSessionNotPending) {
+		t.Fatalf("Round2 on another session after the abort: expected ErrSessionNotPending, got %v", err)
+	}
+
+	// Repeating Round 1 must not reopen the aborted session.
+	signers[0].Round1(sessionID, prfKey, signerIDs)
+	if _, err := signers[0].Round2(sessionID, "message", prfKey, signerIDs, round1Data); !errors.Is(err, ErrSessionAborted) {
+		t.Fatalf("expected ErrSessionAborted after repeating Round 1, got %v", err)
+	}
+
+	// A fresh session still signs.
+	message := "after abort"
+	sig, err := signSession(signers, signerIDs, sessionID+1, message)
+	if err != nil {
+		t.Fatalf("signing a fresh session failed: %v", err)
+	}
+	if !Verify(groupKey, message, sig) {
+		t.Error("signature verification failed")
+	}
+}
+
+func TestRound2OncePerSession(t *testing.T) {
+	shares, _, err := GenerateKeys(2, 3, nil)
+	if err != nil {
+		t.Fatalf("GenerateKeys failed: %v", err)
+	}
+	signers := newSigners(shares)
+	prfKey := []byte("test-prf-key-32-bytes-long!!!!!!")
+	signerIDs := []int{0, 1}
+	sessionID := 1
+
+	round1Data := make(map[int]*Round1Data)
+	for _, id := range signerIDs {
+		round1Data[id] = signers[id].Round1(sessionID, prfKey, signerIDs)
+	}
+
+	// Round2 on a session other than the one Round 1 ran for has no mask.
+	if _, err := signers[0].Round2(sessionID+1, "message", prfKey, signerIDs, round1Data); !errors.Is(err, ErrSessionNotPending) {
+		t.Fatalf("Round2 on another session: expected ErrSessionNotPending, got %v", err)
+	}
+
+	if _, err := signers[0].Round2(sessionID, "message", prfKey, signerIDs, round1Data); err != nil {
+		t.Fatalf("Round2 failed: %v", err)
+	}
+	if signers[0].party.R != nil || signers[0].party.D != nil {
+		t.Error("Round2 left the Round 1 mask behind after releasing z")
+	}
+
+	// A second z under another challenge would leak the share.
+	if _, err := signers[0].Round2(sessionID, "other message", prfKey, signerIDs, round1Data); !errors.Is(err, ErrSessionNotPending) {
+		t.Fatalf("second Round2 on the same session: expected ErrSessionNotPending, got %v", err)
+	}
+}
+
+func TestSignerOrderIndependence(t *testing.T) {
+	shares, groupKey, err := GenerateKeys(2, 3, nil)
+	if err != nil {
+		t.Fatalf("GenerateKeys failed: %v", err)
+	}
+	signers := newSigners(shares)
+	prfKey := []byte("test-prf-key-32-bytes-long!!!!!!")
+	sessionID := 1
+	message := "order independence"
+
+	// Each party lists the same signer set in its own order.
+	orders := map[int][]int{0: {0, 1, 2}, 1: {2, 0, 1}, 2: {1, 2, 0}}
+
+	round1Data := make(map[int]*Round1Data)
+	for id, order := range orders {
+		round1Data[id] = signers[id].Round1(sessionID, prfKey, order)
+	}
+	round2Data := make(map[int]*Round2Data)
+	for id, order := range orders {
+		data, err := signers[id].Round2(sessionID, message, prfKey, order, round1Data)
+		if err != nil {
+			t.Fatalf("Round2 failed for party %d with order %v: %v", id, order, err)
+		}
+		round2Data[id] = data
+	}
+
+	sig, err := signers[0].Finalize(round2Data)
+	if err != nil {
+		t.Fatalf("Finalize failed: %v", err)
+	}
+	if !Verify(groupKey, message, sig) {
+		t.Error("signature verification failed")
+	}
+}
+
+func TestNewParamsUnsupportedRing(t *testing.T) {
+	tests := []struct {
+		name string
+		edit func(p *sign.Preset)
+	}{
+		{name: "smaller ring", edit: func(p *sign.Preset) { p.LogN = 7 }},
+		{name: "no 2N-th root of unity", edit: func(p *sign.Preset) { p.LogN = 10 }},
+		{name: "other NTT-friendly Q", edit: func(p *sign.Preset) { p.Q = 8380417 }},
+		{name: "other QXi", edit: func(p *sign.Preset) { p.QXi *= 2 }},
+		{name: "other QNu", edit: func(p *sign.Preset) { p.QNu /= 2 }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			preset, err := sign.NewParamsPreset(DefaultPreset)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tt.edit(preset)
+			params, err := NewParamsFromPreset(preset)
+			if !errors.Is(err, ErrInvalidParams) {
+				t.Fatalf("expected ErrInvalidParams, got %v", err)
+			}
+			if params != nil {
+				t.Error("expected nil params on error")
+			}
+		})
+	}
+
+	params, err := NewParams()
+	if err != nil {
+		t.Fatalf("NewParams failed: %v", err)
+	}
+	if params.R.N() != 1<<sign.LogN || params.R.Modulus().Uint64() != sign.Q {
+		t.Error("NewParams does not use the default ring")
+	}
+}
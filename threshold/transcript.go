@@ -0,0 +1,115 @@
+// Copyright (C) 2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package threshold
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/luxfi/ringtail/primitives"
+	"github.com/luxfi/ringtail/utils"
+
+	"github.com/luxfi/lattice/v7/ring"
+	"github.com/luxfi/lattice/v7/utils/structs"
+)
+
+// Transcript is what a signer hashes at the start of Round 2, after checking
+// the Round 1 MACs: A, BTilde, the session ID, the signer set and every
+// signer's D. Parties whose transcripts differ derive different challenges.
+type Transcript struct {
+	A         structs.Matrix[ring.Poly]
+	BTilde    structs.Vector[ring.Poly]
+	SessionID int
+	Signers   []int // Canonical signer set
+	D         map[int]structs.Matrix[ring.Poly]
+	Params    *Params // Rings of the group key, to compare polynomials over
+}
+
+// NewTranscript collects the transcript a signer under groupKey hashes for
+// sessionID from the Round 1 data it received.
+func NewTranscript(groupKey *GroupKey, sessionID int, signers []int, round1Data map[int]*Round1Data) *Transcript {
+	D := make(map[int]structs.Matrix[ring.Poly], len(round1Data))
+	for _, data := range round1Data {
+		D[data.PartyID] = data.D
+	}
+	return &Transcript{
+		A:         groupKey.A,
+		BTilde:    groupKey.BTilde,
+		SessionID: sessionID,
+		Signers:   primitives.CanonicalSignerSet(signers),
+		D:         D,
+		Params:    groupKey.Params,
+	}
+}
+
+// DiffTranscript names the first field in which a and b differ, in the order
+// they are hashed: "A[i][j]", "BTilde[i]", "sid", "T", then "D[p]" if party p
+// is missing from one side or its D has another shape, or "D[p][i][j]". It is
+// meant for debugging parties that disagree, such as on a failed MAC check.
+// equal is true, with an empty name, if the transcripts match. Polynomials are
+// compared over the rings of a.Params, so a must come from NewTranscript.
+func DiffTranscript(a, b *Transcript) (firstDivergence string, equal bool) {
+	r := a.Params.R
+	if field := diffMatrix(r, "A", a.A, b.A); field != "" {
+		return field, false
+	}
+	if len(a.BTilde) != len(b.BTilde) {
+		return "BTilde", false
+	}
+	for i := range a.BTilde {
+		if !utils.PolyEqual(a.Params.RXi, a.BTilde[i], b.BTilde[i]) {
+			return fmt.Sprintf("BTilde[%d]", i), false
+		}
+	}
+	if a.SessionID != b.SessionID {
+		return "sid", false
+	}
+	if !slices.Equal(primitives.CanonicalSignerSet(a.Signers), primitives.CanonicalSignerSet(b.Signers)) {
+		return "T", false
+	}
+
+	parties := make([]int, 0, len(a.D)+len(b.D))
+	for p := range a.D {
+		parties = append(parties, p)
+	}
+	for p := range b.D {
+		if _, ok := a.D[p]; !ok {
+			parties = append(parties, p)
+		}
+	}
+	sort.Ints(parties)
+	for _, p := range parties {
+		Da, okA := a.D[p]
+		Db, okB := b.D[p]
+		if !okA || !okB {
+			return fmt.Sprintf("D[%d]", p), false
+		}
+		if field := diffMatrix(r, fmt.Sprintf("D[%d]", p), Da, Db); field != "" {
+			return field, false
+		}
+	}
+	return "", true
+}
+
+// diffMatrix names the first entry of name where a and b differ, or name
+// itself if their shapes differ. It returns "" if they are equal over r.
+func diffMatrix(r *ring.Ring, name string, a, b structs.Matrix[ring.Poly]) string {
+	if len(a) != len(b) {
+		return name
+	}
+	for i := range a {
+		if len(a[i]) != len(b[i]) {
+			return name
+		}
+	}
+	for i := range a {
+		for j := range a[i] {
+			if !utils.PolyEqual(r, a[i][j], b[i][j]) {
+				return fmt.Sprintf("%s[%d][%d]", name, i, j)
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,76 @@
+// Copyright (C) 2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package threshold
+
+import (
+	"testing"
+
+	"github.com/luxfi/lattice/v7/ring"
+	"github.com/luxfi/lattice/v7/utils/structs"
+)
+
+func TestDiffTranscript(t *testing.T) {
+	shares, groupKey, err := GenerateKeys(2, 3, nil)
+	if err != nil {
+		t.Fatalf("GenerateKeys failed: %v", err)
+	}
+	prfKey := []byte("test-prf-key-32-bytes-long!!!!!!")
+	signerIDs := []int{0, 1, 2}
+	round1Data := make(map[int]*Round1Data)
+	for _, id := range signerIDs {
+		round1Data[id] = NewSigner(shares[id]).Round1(1, prfKey, signerIDs)
+	}
+	base := NewTranscript(groupKey, 1, signerIDs, round1Data)
+
+	if field, equal := DiffTranscript(base, NewTranscript(shares[1].GroupKey, 1, []int{2, 0, 1}, round1Data)); !equal || field != "" {
+		t.Fatalf("identical transcripts differ at %q", field)
+	}
+
+	// tamperD returns the transcript with one coefficient of D_1[2][3] changed.
+	tamperD := func() *Transcript {
+		D := make(structs.Matrix[ring.Poly], len(round1Data[1].D))
+		for i, row := range round1Data[1].D {
+			D[i] = append([]ring.Poly(nil), row...)
+		}
+		D[2][3] = *D[2][3].CopyNew()
+		D[2][3].Coeffs[0][0]++
+		tr := NewTranscript(groupKey, 1, signerIDs, round1Data)
+		tr.D[1] = D
+		return tr
+	}
+	dropD := func() *Transcript {
+		tr := NewTranscript(groupKey, 1, signerIDs, round1Data)
+		delete(tr.D, 2)
+		return tr
+	}
+	otherSession := NewTranscript(groupKey, 2, signerIDs, round1Data)
+	otherSigners := NewTranscript(groupKey, 1, []int{0, 1}, round1Data)
+	_, otherKey, err := GenerateKeys(2, 3, nil)
+	if err != nil {
+		t.Fatalf("GenerateKeys failed: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		other *Transcript
+		want  string
+	}{
+		{name: "one D entry", other: tamperD(), want: "D[1][2][3]"},
+		{name: "missing D", other: dropD(), want: "D[2]"},
+		{name: "session", other: otherSession, want: "sid"},
+		{name: "signers", other: otherSigners, want: "T"},
+		{name: "group key", other: NewTranscript(otherKey, 1, signerIDs, round1Data), want: "A[0][0]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			field, equal := DiffTranscript(base, tt.other)
+			if equal || field != tt.want {
+				t.Errorf("DiffTranscript = %q, %v; want %q, false", field, equal, tt.want)
+			}
+			if reversed, _ := DiffTranscript(tt.other, base); reversed != tt.want {
+				t.Errorf("reversed DiffTranscript = %q, want %q", reversed, tt.want)
+			}
+		})
+	}
+}
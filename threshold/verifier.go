@@ -0,0 +1,172 @@
+// Copyright (C) 2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package threshold
+
+import (
+	"container/list"
+	"encoding/binary"
+	"io"
+	"sync"
+
+	"github.com/luxfi/ringtail/utils"
+
+	"github.com/luxfi/lattice/v7/ring"
+	"github.com/zeebo/blake3"
+)
+
+const signatureDigestTag = "RingtailSignatureDigestV1"
+
+// verifiedSignature identifies one (group key, message, signature) triple.
+type verifiedSignature struct {
+	key       [32]byte // VerificationKeyDigest
+	message   [32]byte
+	signature [32]byte
+}
+
+// VerifierStats counts the cache lookups of a Verifier.
+type VerifierStats struct {
+	Hits    uint64
+	Misses  uint64
+	Entries int
+}
+
+// HitRate returns the fraction of lookups answered from the cache.
+func (s VerifierStats) HitRate() float64 {
+	if s.Hits+s.Misses == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Hits+s.Misses)
+}
+
+// Verifier verifies signatures like Verify and remembers the last size
+// signatures that verified, so a block seen again through gossip or a reorg
+// check is accepted without repeating the lattice arithmetic. Only successes
+// are cached: invalid signatures cost a full verification every time, but
+// cannot flood the cache and evict valid ones. Group keys passed to Verify
+// must not be modified afterwards. A Verifier is safe for concurrent use.
+type Verifier struct {
+	size int
+
+	mu      sync.Mutex
+	entries map[verifiedSignature]*list.Element
+	order   *list.List // Most recently used at the front
+	stats   VerifierStats
+
+	// The digest of the last group key seen, which is the current epoch's
+	// key in steady state, so it is not rehashed on every call.
+	lastKey    *GroupKey
+	lastDigest [32]byte
+
+	verify func(groupKey *GroupKey, message string, sig *Signature) bool
+}
+
+// NewVerifier returns a Verifier caching up to size verified signatures.
+// A size of zero or less disables the cache.
+func NewVerifier(size int) *Verifier {
+	return &Verifier{
+		size:    size,
+		entries: make(map[verifiedSignature]*list.Element),
+		order:   list.New(),
+		verify:  Verify,
+	}
+}
+
+// Verify reports whether sig is a valid signature on message under groupKey.
+func (v *Verifier) Verify(groupKey *GroupKey, message string, sig *Signature) bool {
+	if v.size <= 0 || groupKey == nil || sig == nil {
+		return v.verify(groupKey, message, sig)
+	}
+
+	id := verifiedSignature{
+		key:       v.keyDigest(groupKey),
+		message:   blake3.Sum256([]byte(message)),
+		signature: signatureDigest(sig),
+	}
+	v.mu.Lock()
+	if elem, ok := v.entries[id]; ok {
+		v.order.MoveToFront(elem)
+		v.stats.Hits++
+		v.mu.Unlock()
+		return true
+	}
+	v.stats.Misses++
+	v.mu.Unlock()
+
+	if !v.verify(groupKey, message, sig) {
+		return false
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if _, ok := v.entries[id]; !ok {
+		v.entries[id] = v.order.PushFront(id)
+		if v.order.Len() > v.size {
+			oldest := v.order.Back()
+			v.order.Remove(oldest)
+			delete(v.entries, oldest.Value.(verifiedSignature))
+		}
+	}
+	return true
+}
+
+// Stats returns the cache counters so far.
+func (v *Verifier) Stats() VerifierStats {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	stats := v.stats
+	stats.Entries = v.order.Len()
+	return stats
+}
+
+func (v *Verifier) keyDigest(groupKey *GroupKey) [32]byte {
+	v.mu.Lock()
+	if groupKey == v.lastKey {
+		digest := v.lastDigest
+		v.mu.Unlock()
+		return digest
+	}
+	v.mu.Unlock()
+
+	digest := VerificationKeyDigest(groupKey)
+	v.mu.Lock()
+	v.lastKey, v.lastDigest = groupKey, digest
+	v.mu.Unlock()
+	return digest
+}
+
+// signatureDigest hashes every coefficient of sig, so two signatures share a
+// digest only if they are encoded identically.
+func signatureDigest(sig *Signature) [32]byte {
+	hasher := blake3.New()
+	_, _ = io.WriteString(hasher, signatureDigestTag)
+	writePoly := func(p ring.Poly) {
+		writeDigestUint64(hasher, uint64(len(p.Coeffs)))
+		for _, level := range p.Coeffs {
+			writeDigestUint64(hasher, uint64(len(level)))
+			b := make([]byte, 8*len(level))
+			for i, c := range level {
+				utils.TranscriptByteOrder().PutUint64(b[8*i:], c)
+			}
+			_, _ = hasher.Write(b)
+		}
+	}
+	writePoly(sig.C)
+	writeDigestUint64(hasher, uint64(len(sig.Z)))
+	for _, p := range sig.Z {
+		writePoly(p)
+	}
+	writeDigestUint64(hasher, uint64(len(sig.Delta)))
+	for _, p := range sig.Delta {
+		writePoly(p)
+	}
+	var digest [32]byte
+	copy(digest[:], hasher.Sum(nil))
+	return digest
+}
+
+func writeDigestUint64(w io.Writer, v uint64) {
+	var b [8]byte
+	utils.TranscriptByteOrder().PutUint64(b[:], v)
+	_, _ = w.Write(b[:])
+}
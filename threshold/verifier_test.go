@@ -0,0 +1,89 @@
+// Copyright (C) 2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package threshold
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestVerifierCache(t *testing.T) {
+	shares, groupKey, err := GenerateKeys(2, 3, nil)
+	if err != nil {
+		t.Fatalf("GenerateKeys failed: %v", err)
+	}
+	signers := newSigners(shares)
+	messages := make([]string, 3)
+	sigs := make([]*Signature, 3)
+	for i := range sigs {
+		messages[i] = fmt.Sprintf("block %d", i)
+		if sigs[i], err = signSession(signers, []int{0, 1}, i+1, messages[i]); err != nil {
+			t.Fatalf("signing %q failed: %v", messages[i], err)
+		}
+	}
+
+	v := NewVerifier(2)
+	if !v.Verify(groupKey, messages[0], sigs[0]) {
+		t.Fatal("first verification failed")
+	}
+
+	// Replace the lattice check: a hit must not reach it.
+	calls := 0
+	v.verify = func(*GroupKey, string, *Signature) bool {
+		calls++
+		return false
+	}
+	if !v.Verify(groupKey, messages[0], sigs[0]) {
+		t.Error("cached signature was not accepted")
+	}
+	if calls != 0 {
+		t.Errorf("cache hit ran verification %d times", calls)
+	}
+	if v.Verify(groupKey, messages[1], sigs[0]) {
+		t.Error("signature was accepted for another message")
+	}
+	if calls != 1 {
+		t.Errorf("cache miss ran verification %d times, want 1", calls)
+	}
+
+	mauled := &Signature{C: *sigs[0].C.CopyNew(), Z: sigs[0].Z, Delta: sigs[0].Delta}
+	mauled.C.Coeffs[0][0] ^= 1
+	if v.Verify(groupKey, messages[0], mauled) {
+		t.Error("a modified signature hit the cache")
+	}
+
+	// Filling the cache evicts the least recently used signature.
+	v.verify = Verify
+	for i := 1; i < 3; i++ {
+		if !v.Verify(groupKey, messages[i], sigs[i]) {
+			t.Fatalf("verification of %q failed", messages[i])
+		}
+	}
+	calls = 0
+	v.verify = func(groupKey *GroupKey, message string, sig *Signature) bool {
+		calls++
+		return Verify(groupKey, message, sig)
+	}
+	if !v.Verify(groupKey, messages[0], sigs[0]) || calls != 1 {
+		t.Errorf("evicted signature: %d verifications, want 1", calls)
+	}
+
+	stats := v.Stats()
+	if stats.Hits != 1 || stats.Misses != 6 || stats.Entries != 2 {
+		t.Errorf("stats %+v, want 1 hit, 6 misses, 2 entries", stats)
+	}
+	if got, want := stats.HitRate(), 1.0/7; got != want {
+		t.Errorf("hit rate %v, want %v", got, want)
+	}
+
+	disabled := NewVerifier(0)
+	for i := 0; i < 2; i++ {
+		if !disabled.Verify(groupKey, messages[0], sigs[0]) {
+			t.Error("verification without a cache failed")
+		}
+	}
+	if stats := disabled.Stats(); stats.Hits != 0 || stats.Misses != 0 || stats.Entries != 0 {
+		t.Errorf("disabled cache recorded %+v", stats)
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright (C) 2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package threshold
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/luxfi/ringtail/primitives"
+	"github.com/luxfi/ringtail/utils"
+
+	"github.com/luxfi/lattice/v7/ring"
+	"github.com/luxfi/lattice/v7/utils/structs"
+)
+
+// shamirStreamTag separates the keygen Shamir stream from other uses of the dealer key.
+const shamirStreamTag = "RingtailShamirStreamV1"
+
+// slotRange returns the share slots [start, end) held by party. Slots are
+// assigned to parties in index order.
+func (gk *GroupKey) slotRange(party int) (start, end int) {
+	for i := 0; i < party; i++ {
+		start += gk.Weights[i]
+	}
+	return start, start + gk.Weights[party]
+}
+
+// signerSlots checks that signers are distinct parties whose weights reach
+// the threshold and returns every slot they hold, in signer order.
+func (gk *GroupKey) signerSlots(signers []int) ([]int, error) {
+	seen := make(map[int]bool, len(signers))
+	weight := 0
+	var slots []int
+	for _, j := range signers {
+		if j < 0 || j >= len(gk.Weights) {
+			return nil, fmt.Errorf("%w: %d", ErrInvalidPartyIndex, j)
+		}
+		if seen[j] {
+			return nil, fmt.Errorf("%w: duplicate signer %d", ErrInvalidPartyIndex, j)
+		}
+		seen[j] = true
+		weight += gk.Weights[j]
+
+		start, end := gk.slotRange(j)
+		for slot := start; slot < end; slot++ {
+			slots = append(slots, slot)
+		}
+	}
+	if weight < gk.Threshold {
+		return nil, fmt.Errorf("%w: %d < %d", ErrInsufficientWeight, weight, gk.Threshold)
+	}
+	return slots, nil
+}
+
+// slotLambdas returns the Lagrange coefficient of every slot held by signers,
+// taken over all of those slots, in NTT and Montgomery form.
+func (gk *GroupKey) slotLambdas(r *ring.Ring, signers []int) (map[int]ring.Poly, error) {
+	slots, err := gk.signerSlots(signers)
+	if err != nil {
+		return nil, err
+	}
+	lambdas := primitives.ComputeLagrangeCoefficients(r, slots, r.Modulus())
+	lambdaOf := make(map[int]ring.Poly, len(slots))
+	for i, slot := range slots {
+		r.NTT(lambdas[i], lambdas[i])
+		r.MForm(lambdas[i], lambdas[i])
+		lambdaOf[slot] = lambdas[i]
+	}
+	return lambdaOf, nil
+}
+
+// maxCachedLambdaSets bounds the signer sets a Signer caches Lagrange
+// coefficients for. The cache is emptied when it fills.
+const maxCachedLambdaSets = 64
+
+// LagrangeCoefficients returns the Lagrange coefficient of every slot held by
+// signers, taken over all of those slots, in NTT and Montgomery form. They are
+// computed once per signer set and cached, as a node typically signs many
+// messages with the same set within an epoch. The order of signers does not
+// matter. The returned polynomials are copies.
+func (s *Signer) LagrangeCoefficients(signers []int) (map[int]ring.Poly, error) {
+	lambdas, err := s.slotLambdas(signers)
+	if err != nil {
+		return nil, err
+	}
+	copies := make(map[int]ring.Poly, len(lambdas))
+	for slot, lambda := range lambdas {
+		copies[slot] = *lambda.CopyNew()
+	}
+	return copies, nil
+}
+
+// slotLambdas is LagrangeCoefficients without the copy; callers must not
+// modify the result.
+func (s *Signer) slotLambdas(signers []int) (map[int]ring.Poly, error) {
+	signers = primitives.CanonicalSignerSet(signers)
+	key := fmt.Sprint(signers)
+	s.mu.Lock()
+	lambdas, ok := s.lambdas[key]
+	s.mu.Unlock()
+	if ok {
+		return lambdas, nil
+	}
+
+	lambdas, err := s.share.GroupKey.slotLambdas(s.params.R, signers)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	if len(s.lambdas) >= maxCachedLambdaSets {
+		clear(s.lambdas)
+	}
+	s.lambdas[key] = lambdas
+	s.mu.Unlock()
+	return lambdas, nil
+}
+
+// combinedShare returns the sum of λ_k s_k over this party's slots k, with the
+// Lagrange coefficients taken over every slot held by signers. The shares of
+// all signers then sum to the group secret. The result is in NTT and
+// Montgomery form like SkShare.
+func (s *Signer) combinedShare(signers []int) (structs.Vector[ring.Poly], error) {
+	if !slices.Contains(signers, s.share.Index) {
+		return nil, fmt.Errorf("%w: party %d is not a signer", ErrInvalidPartyIndex, s.share.Index)
+	}
+	r := s.params.R
+	lambdaOf, err := s.slotLambdas(signers)
+	if err != nil {
+		return nil, err
+	}
+
+	combined := utils.InitializeVector(r, len(s.share.SkShare))
+	weighted := utils.InitializeVector(r, len(s.share.SkShare))
+	for k, slot := range s.share.Slots {
+		utils.VectorPolyMul(r, s.share.SlotShares[k], lambdaOf[slot], weighted)
+		utils.VectorAdd(r, combined, weighted, combined)
+	}
+	return combined, nil
+}
+
+// montgomeryOne returns the constant 1 in NTT and Montgomery form.
+func montgomeryOne(r *ring.Ring) ring.Poly {
+	one := r.NewMonomialXi(0)
+	r.NTT(one, one)
+	r.MForm(one, one)
+	return one
+}
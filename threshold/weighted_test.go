@@ -0,0 +1,147 @@
+// Copyright (C) 2025, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package threshold
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestGenerateWeightedKeysErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		weights   []int
+		threshold int
+		wantErr   error
+	}{
+		{name: "single party", weights: []int{5}, threshold: 1, wantErr: ErrInvalidPartyCount},
+		{name: "zero weight", weights: []int{2, 0, 1}, threshold: 2, wantErr: ErrInvalidWeight},
+		{name: "zero threshold", weights: []int{2, 1}, threshold: 0, wantErr: ErrInvalidThreshold},
+		{name: "threshold above total weight", weights: []int{2, 1}, threshold: 4, wantErr: ErrInvalidThreshold},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := GenerateWeightedKeys(tt.weights, tt.threshold, nil); !errors.Is(err, tt.wantErr) {
+				t.Errorf("GenerateWeightedKeys() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWeightedThresholdSigning(t *testing.T) {
+	shares, groupKey, err := GenerateWeightedKeys([]int{3, 1, 1}, 3, nil)
+	if err != nil {
+		t.Fatalf("GenerateWeightedKeys failed: %v", err)
+	}
+	if got := len(shares[0].Slots); got != 3 {
+		t.Fatalf("party 0 holds %d slots, want 3", got)
+	}
+
+	tests := []struct {
+		name      string
+		signerIDs []int
+		wantErr   error
+	}{
+		{name: "heavy party alone", signerIDs: []int{0}},
+		{name: "heavy and light party", signerIDs: []int{0, 2}},
+		{name: "everyone", signerIDs: []int{0, 1, 2}},
+		{name: "light parties only", signerIDs: []int{1, 2}, wantErr: ErrInsufficientWeight},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			message := "weighted " + tt.name
+			sig, err := signSession(newSigners(shares), tt.signerIDs, i+1, message)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("signing failed: %v", err)
+			}
+			if !Verify(groupKey, message, sig) {
+				t.Error("signature verification failed")
+			}
+		})
+	}
+}
+
+func TestThresholdSubsetsSign(t *testing.T) {
+	shares, groupKey, err := GenerateKeys(2, 3, nil)
+	if err != nil {
+		t.Fatalf("GenerateKeys failed: %v", err)
+	}
+
+	for i, signerIDs := range [][]int{{0, 1}, {0, 2}, {1, 2}} {
+		t.Run(fmt.Sprint(signerIDs), func(t *testing.T) {
+			message := fmt.Sprintf("subset %v", signerIDs)
+			sig, err := signSession(newSigners(shares), signerIDs, i+1, message)
+			if err != nil {
+				t.Fatalf("signing failed: %v", err)
+			}
+			if !Verify(groupKey, message, sig) {
+				t.Error("signature verification failed")
+			}
+		})
+	}
+
+	if _, err := signSession(newSigners(shares), []int{1}, 10, "too few"); !errors.Is(err, ErrInsufficientWeight) {
+		t.Errorf("expected ErrInsufficientWeight for a single signer, got %v", err)
+	}
+}
+
+func TestLagrangeCoefficientsCache(t *testing.T) {
+	shares, groupKey, err := GenerateKeys(2, 3, nil)
+	if err != nil {
+		t.Fatalf("GenerateKeys failed: %v", err)
+	}
+	r := groupKey.Params.R
+	s := NewSigner(shares[0])
+
+	cached, err := s.LagrangeCoefficients([]int{1, 0})
+	if err != nil {
+		t.Fatalf("LagrangeCoefficients failed: %v", err)
+	}
+	again, err := s.LagrangeCoefficients([]int{0, 1})
+	if err != nil {
+		t.Fatalf("LagrangeCoefficients failed: %v", err)
+	}
+	if len(s.lambdas) != 1 {
+		t.Fatalf("cache holds %d signer sets, want 1", len(s.lambdas))
+	}
+	fresh, err := groupKey.slotLambdas(r, []int{0, 1})
+	if err != nil {
+		t.Fatalf("slotLambdas failed: %v", err)
+	}
+	if len(cached) != len(fresh) {
+		t.Fatalf("got %d coefficients, want %d", len(cached), len(fresh))
+	}
+	for slot, lambda := range fresh {
+		if !r.Equal(cached[slot], lambda) || !r.Equal(again[slot], lambda) {
+			t.Errorf("slot %d: cached coefficient differs from a fresh computation", slot)
+		}
+	}
+
+	other, err := s.LagrangeCoefficients([]int{0, 2})
+	if err != nil {
+		t.Fatalf("LagrangeCoefficients failed: %v", err)
+	}
+	if r.Equal(other[0], cached[0]) {
+		t.Error("slot 0 has the same coefficient for signer sets {0, 1} and {0, 2}")
+	}
+	if _, ok := other[1]; ok {
+		t.Error("coefficients for {0, 2} include slot 1")
+	}
+	if len(s.lambdas) != 2 {
+		t.Errorf("cache holds %d signer sets, want 2", len(s.lambdas))
+	}
+
+	if _, err := s.LagrangeCoefficients([]int{0}); !errors.Is(err, ErrInsufficientWeight) {
+		t.Errorf("expected ErrInsufficientWeight, got %v", err)
+	}
+}
@@ -0,0 +1,42 @@
+package utils
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// Byte-order conventions for everything Ringtail hashes or puts on the wire.
+// Independent implementations must follow these to interoperate byte-for-byte.
+// They are functions rather than variables so no caller can change them.
+
+// TranscriptByteOrder encodes the integers (sid, party IDs, set sizes)
+// absorbed into the BLAKE3 transcripts in the primitives package.
+func TranscriptByteOrder() binary.ByteOrder { return binary.BigEndian }
+
+// WireByteOrder encodes the length prefixes and map keys of the networking
+// frames.
+func WireByteOrder() binary.ByteOrder { return binary.BigEndian }
+
+// CoefficientByteOrder is the order of each uint64 coefficient inside the
+// lattice WriteTo/ReadFrom encoding of polynomials, vectors and matrices. It
+// is fixed by the lattice library; it is listed here so the convention is
+// pinned next to the others.
+func CoefficientByteOrder() binary.ByteOrder { return binary.LittleEndian }
+
+// ReorderWords rewrites b, a sequence of uint64 words in the byte order from,
+// into the byte order to, in place. The lattice encoding is such a sequence in
+// CoefficientByteOrder, lengths and coefficients alike, so this converts it
+// for a peer that expects the other order. It fails if len(b) is not a
+// multiple of 8.
+func ReorderWords(b []byte, from, to binary.ByteOrder) error {
+	if len(b)%8 != 0 {
+		return fmt.Errorf("%d bytes is not a whole number of uint64 words", len(b))
+	}
+	if from == to {
+		return nil
+	}
+	for i := 0; i < len(b); i += 8 {
+		to.PutUint64(b[i:], from.Uint64(b[i:]))
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/luxfi/lattice/v7/ring"
+	"github.com/luxfi/lattice/v7/utils/structs"
+)
+
+func TestByteOrderReference(t *testing.T) {
+	tests := []struct {
+		name  string
+		order binary.ByteOrder
+		value any
+		want  []byte
+	}{
+		{name: "transcript sid", order: TranscriptByteOrder(), value: int64(0x0102030405060708), want: []byte{1, 2, 3, 4, 5, 6, 7, 8}},
+		{name: "transcript party", order: TranscriptByteOrder(), value: int32(-2), want: []byte{0xff, 0xff, 0xff, 0xfe}},
+		{name: "wire length", order: WireByteOrder(), value: uint32(0x01020304), want: []byte{1, 2, 3, 4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			if err := binary.Write(buf, tt.order, tt.value); err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(buf.Bytes(), tt.want) {
+				t.Errorf("encoded %x, want %x", buf.Bytes(), tt.want)
+			}
+		})
+	}
+}
+
+func TestCoefficientByteOrder(t *testing.T) {
+	r, err := ring.NewRing(256, []uint64{0x1000000004A01})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const coeff = 0x010203040506
+	poly := r.NewPoly()
+	poly.Coeffs[0][0] = coeff
+	vec := structs.Vector[ring.Poly]{poly}
+
+	buf := new(bytes.Buffer)
+	if _, err := vec.WriteTo(buf); err != nil {
+		t.Fatal(err)
+	}
+
+	want := make([]byte, 8)
+	CoefficientByteOrder().PutUint64(want, coeff)
+	if !bytes.Contains(buf.Bytes(), want) {
+		t.Errorf("serialized vector does not contain coefficient bytes %x", want)
+	}
+
+	bigEndian := make([]byte, 8)
+	binary.BigEndian.PutUint64(bigEndian, coeff)
+	if bytes.Contains(buf.Bytes(), bigEndian) {
+		t.Errorf("serialized vector contains the big-endian coefficient %x", bigEndian)
+	}
+
+	decoded := structs.Vector[ring.Poly]{}
+	if _, err := decoded.ReadFrom(bytes.NewReader(buf.Bytes())); err != nil {
+		t.Fatal(err)
+	}
+	if len(decoded) != 1 || decoded[0].Coeffs[0][0] != coeff {
+		t.Error("decoded vector does not round-trip the coefficient")
+	}
+}
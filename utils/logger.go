@@ -0,0 +1,19 @@
+package utils
+
+// Logger receives structured events from signers and the networking layer.
+// The arguments after msg are alternating key/value pairs, so a *slog.Logger
+// satisfies the interface directly.
+type Logger interface {
+	Debug(msg string, args ...any)
+	Info(msg string, args ...any)
+	Warn(msg string, args ...any)
+	Error(msg string, args ...any)
+}
+
+// NopLogger discards every event. It is the default wherever a Logger can be injected.
+type NopLogger struct{}
+
+func (NopLogger) Debug(string, ...any) {}
+func (NopLogger) Info(string, ...any)  {}
+func (NopLogger) Warn(string, ...any)  {}
+func (NopLogger) Error(string, ...any) {}
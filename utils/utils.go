@@ -1,12 +1,18 @@
 package utils
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"math/big"
+	"math/bits"
+	"runtime"
+	"slices"
 	"strings"
+	"sync"
 
 	"github.com/luxfi/lattice/v7/ring"
+	"github.com/luxfi/lattice/v7/utils/sampling"
 	"github.com/luxfi/lattice/v7/utils/structs"
 	"github.com/zeebo/blake3"
 )
@@ -179,6 +185,20 @@ func SamplePolyVector(r *ring.Ring, length int, sampler ring.Sampler, NTT bool,
 	return vector
 }
 
+// SamplePolyVectorInto samples into the polynomials of vec, reusing their
+// storage. It draws the same values as SamplePolyVector with len(vec).
+func SamplePolyVectorInto(r *ring.Ring, vec structs.Vector[ring.Poly], sampler ring.Sampler, NTT bool, montgomery bool) {
+	for i := range vec {
+		sampler.Read(vec[i])
+		if NTT {
+			r.NTT(vec[i], vec[i])
+		}
+		if montgomery {
+			r.MForm(vec[i], vec[i])
+		}
+	}
+}
+
 // SamplePolyMatrix samples a matrix of polynomials with given dimensions (rows and cols) using the provided sampler.
 func SamplePolyMatrix(r *ring.Ring, rows, cols int, sampler ring.Sampler, NTT bool, montgomery bool) structs.Matrix[ring.Poly] {
 	matrix := structs.Matrix[ring.Poly](make([][]ring.Poly, rows))
@@ -197,6 +217,99 @@ func SamplePolyMatrix(r *ring.Ring, rows, cols int, sampler ring.Sampler, NTT bo
 	return matrix
 }
 
+// MaxSampleLength caps the number of polynomials the checked and seeded
+// samplers draw in one call. A request above it is almost certainly a bug and
+// would allocate gigabytes.
+const MaxSampleLength = 1 << 16
+
+// ErrInvalidSampleLength is returned by the checked samplers for a length that
+// is not positive or exceeds MaxSampleLength.
+var ErrInvalidSampleLength = errors.New("invalid sample length")
+
+// SamplePolyVectorChecked is SamplePolyVector that first checks length is in
+// [1, MaxSampleLength], returning ErrInvalidSampleLength otherwise.
+func SamplePolyVectorChecked(r *ring.Ring, length int, sampler ring.Sampler, NTT bool, montgomery bool) (structs.Vector[ring.Poly], error) {
+	if length < 1 || length > MaxSampleLength {
+		return nil, fmt.Errorf("%w: %d polynomials, limit %d", ErrInvalidSampleLength, length, MaxSampleLength)
+	}
+	return SamplePolyVector(r, length, sampler, NTT, montgomery), nil
+}
+
+// SamplePolyMatrixChecked is SamplePolyMatrix that first checks rows and cols
+// are positive and rows·cols is at most MaxSampleLength, returning
+// ErrInvalidSampleLength otherwise.
+func SamplePolyMatrixChecked(r *ring.Ring, rows, cols int, sampler ring.Sampler, NTT bool, montgomery bool) (structs.Matrix[ring.Poly], error) {
+	if rows < 1 || cols < 1 || rows > MaxSampleLength/cols {
+		return nil, fmt.Errorf("%w: %d x %d polynomials, limit %d", ErrInvalidSampleLength, rows, cols, MaxSampleLength)
+	}
+	return SamplePolyMatrix(r, rows, cols, sampler, NTT, montgomery), nil
+}
+
+// seededSampleTag separates the per-index seeds of SamplePolyVectorSeeded
+// from other uses of the seed.
+const seededSampleTag = "RingtailSeededSampleV1"
+
+// SamplePolyVectorSeeded samples length polynomials in parallel, drawing
+// polynomial i from the sampler newSampler builds over a PRNG keyed by
+// BLAKE3(tag || seed || i). Each index has its own stream, so the output
+// depends only on seed and length, not on scheduling, and equals sampling
+// every index in turn. Like SamplePolyVectorChecked, it returns
+// ErrInvalidSampleLength unless length is in [1, MaxSampleLength].
+func SamplePolyVectorSeeded(r *ring.Ring, seed []byte, length int, newSampler func(prng sampling.PRNG) ring.Sampler, NTT bool, montgomery bool) (structs.Vector[ring.Poly], error) {
+	if length < 1 || length > MaxSampleLength {
+		return nil, fmt.Errorf("%w: %d polynomials, limit %d", ErrInvalidSampleLength, length, MaxSampleLength)
+	}
+	vector := make(structs.Vector[ring.Poly], length)
+	errs := make([]error, length)
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(runtime.GOMAXPROCS(0), length); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				vector[i], errs[i] = sampleSeededPoly(r, seededSampleKey(seed, i), newSampler, NTT, montgomery)
+			}
+		}()
+	}
+	for i := 0; i < length; i++ {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return vector, nil
+}
+
+// seededSampleKey returns the PRNG key of polynomial i of SamplePolyVectorSeeded.
+func seededSampleKey(seed []byte, i int) []byte {
+	hasher := blake3.New()
+	_, _ = hasher.Write([]byte(seededSampleTag))
+	_, _ = hasher.Write(seed)
+	var index [8]byte
+	TranscriptByteOrder().PutUint64(index[:], uint64(i))
+	_, _ = hasher.Write(index[:])
+	return hasher.Sum(nil)
+}
+
+func sampleSeededPoly(r *ring.Ring, key []byte, newSampler func(prng sampling.PRNG) ring.Sampler, NTT bool, montgomery bool) (ring.Poly, error) {
+	prng, err := sampling.NewKeyedPRNG(key)
+	if err != nil {
+		return ring.Poly{}, err
+	}
+	p := newSampler(prng).ReadNew()
+	if NTT {
+		r.NTT(p, p)
+	}
+	if montgomery {
+		r.MForm(p, p)
+	}
+	return p, nil
+}
+
 // PRINT FUNCTIONS
 
 func PrintMatrix(label string, matrix structs.Matrix[ring.Poly]) {
@@ -266,6 +379,68 @@ func ConvertVectorFromNTT(r *ring.Ring, vec structs.Vector[ring.Poly]) {
 	}
 }
 
+// VerifyNTTRoundTrip samples trials random polynomials of r and checks that the
+// NTT and Montgomery conversions used by ConvertVectorToNTT and
+// ConvertVectorFromNTT recover each one exactly. Callers can run it at startup
+// as a sanity check on the ring.
+func VerifyNTTRoundTrip(r *ring.Ring, trials int) error {
+	prng, err := sampling.NewPRNG()
+	if err != nil {
+		return err
+	}
+	sampler := ring.NewUniformSampler(prng, r)
+	for i := 0; i < trials; i++ {
+		p := sampler.ReadNew()
+		q := *p.CopyNew()
+		r.NTT(q, q)
+		r.MForm(q, q)
+		r.IMForm(q, q)
+		r.INTT(q, q)
+		if !r.Equal(p, q) {
+			return fmt.Errorf("NTT round trip failed on trial %d", i)
+		}
+	}
+	return nil
+}
+
+// PolyEqual reports whether a and b are the same polynomial of r. Polynomials
+// that share their coefficient arrays, such as a value compared with itself,
+// are equal without reading them; otherwise the coefficients are compared
+// level by level, stopping at the first difference. A checksum would not be
+// faster here, since computing it reads every coefficient of both sides.
+func PolyEqual(r *ring.Ring, a, b ring.Poly) bool {
+	if len(a.Coeffs) != len(b.Coeffs) {
+		return false
+	}
+	for i := range a.Coeffs {
+		ai, bi := a.Coeffs[i], b.Coeffs[i]
+		if len(ai) != r.N() || len(bi) != r.N() {
+			return false
+		}
+		if &ai[0] == &bi[0] {
+			continue
+		}
+		if !slices.Equal(ai, bi) {
+			return false
+		}
+	}
+	return true
+}
+
+// VectorEqual reports whether a and b have the same length and PolyEqual
+// entries.
+func VectorEqual(r *ring.Ring, a, b structs.Vector[ring.Poly]) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !PolyEqual(r, a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
 // INITIALIZE HELPERS
 
 // InitializeVector creates and returns a vector of the given length, initializing each element as a new polynomial.
@@ -522,3 +697,75 @@ func copyMat(matBig [][]*big.Int) [][]*big.Int {
 	}
 	return data
 }
+
+// BatchModInverse returns the inverses of vals modulo the prime Q using Montgomery's trick:
+// a single modular inversion plus O(n) multiplications. Zero entries map to zero.
+func BatchModInverse(vals []uint64, Q uint64) []uint64 {
+	invs := make([]uint64, len(vals))
+	if len(vals) == 0 {
+		return invs
+	}
+
+	// prefix[i] is the product of the non-zero entries of vals[0..i]
+	prefix := make([]uint64, len(vals))
+	acc := uint64(1)
+	for i, v := range vals {
+		if v %= Q; v != 0 {
+			acc = mulMod(acc, v, Q)
+		}
+		prefix[i] = acc
+	}
+
+	inv := modInverse(acc, Q)
+	for i := len(vals) - 1; i >= 0; i-- {
+		v := vals[i] % Q
+		if v == 0 {
+			continue
+		}
+		if i > 0 {
+			invs[i] = mulMod(inv, prefix[i-1], Q)
+		} else {
+			invs[i] = inv
+		}
+		inv = mulMod(inv, v, Q)
+	}
+	return invs
+}
+
+// ErrNotInvertible is returned by PolyDivNTT when a divisor coefficient is zero mod Q.
+var ErrNotInvertible = errors.New("coefficient is not invertible")
+
+// PolyDivNTT divides the NTT-domain coefficients a by b slot-wise modulo the
+// prime Q, returning a[i] * b[i]^-1 mod Q. It fails if the lengths differ or
+// any b[i] is zero mod Q.
+func PolyDivNTT(a, b []uint64, Q uint64) ([]uint64, error) {
+	if len(a) != len(b) {
+		return nil, fmt.Errorf("PolyDivNTT: %d coefficients divided by %d", len(a), len(b))
+	}
+	for i, v := range b {
+		if v%Q == 0 {
+			return nil, fmt.Errorf("%w: divisor coefficient %d", ErrNotInvertible, i)
+		}
+	}
+	quotient := BatchModInverse(b, Q)
+	for i := range quotient {
+		quotient[i] = mulMod(a[i]%Q, quotient[i], Q)
+	}
+	return quotient, nil
+}
+
+// modInverse returns a^-1 mod Q, or 0 if a is not invertible.
+func modInverse(a, Q uint64) uint64 {
+	inv := new(big.Int).ModInverse(new(big.Int).SetUint64(a%Q), new(big.Int).SetUint64(Q))
+	if inv == nil {
+		return 0
+	}
+	return inv.Uint64()
+}
+
+// mulMod returns a*b mod Q for a, b < Q.
+func mulMod(a, b, Q uint64) uint64 {
+	hi, lo := bits.Mul64(a, b)
+	_, rem := bits.Div64(hi, lo, Q)
+	return rem
+}
@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/luxfi/lattice/v7/ring"
@@ -217,6 +218,98 @@ func TestNTTConversions(t *testing.T) {
 	}
 }
 
+func TestVerifyNTTRoundTrip(t *testing.T) {
+	tests := []struct {
+		name    string
+		modulus uint64
+	}{
+		{
+			name:    "dilithium prime",
+			modulus: 8380417,
+		},
+		{
+			name:    "scheme modulus",
+			modulus: 0x1000000004A01,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := ring.NewRing(256, []uint64{tt.modulus})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := VerifyNTTRoundTrip(r, 8); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestPolyEqual(t *testing.T) {
+	r, err := ring.NewRing(256, []uint64{8380417})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prng, _ := sampling.NewPRNG()
+	sampler := ring.NewUniformSampler(prng, r)
+	p := sampler.ReadNew()
+
+	lastDiffers := *p.CopyNew()
+	lastDiffers.Coeffs[0][r.N()-1] = (lastDiffers.Coeffs[0][r.N()-1] + 1) % 8380417
+
+	// Swapping two coefficients keeps their sum and XOR, so a checksum built
+	// from either would collide.
+	swapped := *p.CopyNew()
+	swapped.Coeffs[0][0], swapped.Coeffs[0][1] = 1, 2
+	shifted := *swapped.CopyNew()
+	shifted.Coeffs[0][0], shifted.Coeffs[0][1] = 2, 1
+
+	short := *p.CopyNew()
+	short.Coeffs[0] = short.Coeffs[0][:r.N()/2]
+
+	tests := []struct {
+		name string
+		a, b ring.Poly
+		want bool
+	}{
+		{name: "same poly", a: p, b: p, want: true},
+		{name: "copy", a: p, b: *p.CopyNew(), want: true},
+		{name: "zero polys", a: r.NewPoly(), b: r.NewPoly(), want: true},
+		{name: "last coefficient differs", a: p, b: lastDiffers, want: false},
+		{name: "swapped coefficients", a: swapped, b: shifted, want: false},
+		{name: "wrong degree", a: p, b: short, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PolyEqual(r, tt.a, tt.b); got != tt.want {
+				t.Errorf("PolyEqual = %v, want %v", got, tt.want)
+			}
+			if got := PolyEqual(r, tt.b, tt.a); got != tt.want {
+				t.Errorf("PolyEqual (swapped arguments) = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	v := createTestVector(r, sampler, 3)
+	w := make(structs.Vector[ring.Poly], len(v))
+	for i := range v {
+		w[i] = *v[i].CopyNew()
+	}
+	if !VectorEqual(r, v, w) {
+		t.Error("VectorEqual rejected a copy")
+	}
+	if VectorEqual(r, v, w[:2]) {
+		t.Error("VectorEqual accepted vectors of different lengths")
+	}
+	w[2] = lastDiffers
+	if VectorEqual(r, v, w) {
+		t.Error("VectorEqual accepted vectors differing in the last entry")
+	}
+}
+
 func TestSamplePolyVector(t *testing.T) {
 	r, err := ring.NewRing(256, []uint64{8380417})
 	if err != nil {
@@ -309,6 +402,119 @@ func TestSamplePolyMatrix(t *testing.T) {
 	}
 }
 
+func TestCheckedSamplers(t *testing.T) {
+	r, err := ring.NewRing(256, []uint64{8380417})
+	if err != nil {
+		t.Fatal(err)
+	}
+	prng, _ := sampling.NewPRNG()
+	sampler := ring.NewUniformSampler(prng, r)
+
+	vectorTests := []struct {
+		name    string
+		length  int
+		wantErr bool
+	}{
+		{name: "zero", length: 0, wantErr: true},
+		{name: "negative", length: -1, wantErr: true},
+		{name: "over cap", length: MaxSampleLength + 1, wantErr: true},
+		{name: "valid", length: 4},
+	}
+	for _, tt := range vectorTests {
+		t.Run("vector "+tt.name, func(t *testing.T) {
+			v, err := SamplePolyVectorChecked(r, tt.length, sampler, false, false)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidSampleLength) || v != nil {
+					t.Errorf("got %d polynomials and %v, want ErrInvalidSampleLength", len(v), err)
+				}
+				return
+			}
+			if err != nil || len(v) != tt.length {
+				t.Errorf("got %d polynomials and %v, want %d", len(v), err, tt.length)
+			}
+		})
+	}
+
+	matrixTests := []struct {
+		name       string
+		rows, cols int
+		wantErr    bool
+	}{
+		{name: "zero rows", rows: 0, cols: 3, wantErr: true},
+		{name: "negative cols", rows: 3, cols: -2, wantErr: true},
+		{name: "over cap", rows: MaxSampleLength, cols: 2, wantErr: true},
+		{name: "product overflows int", rows: 1 << 40, cols: 1 << 40, wantErr: true},
+		{name: "valid", rows: 2, cols: 3},
+	}
+	for _, tt := range matrixTests {
+		t.Run("matrix "+tt.name, func(t *testing.T) {
+			m, err := SamplePolyMatrixChecked(r, tt.rows, tt.cols, sampler, false, false)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidSampleLength) || m != nil {
+					t.Errorf("got %d rows and %v, want ErrInvalidSampleLength", len(m), err)
+				}
+				return
+			}
+			if err != nil || len(m) != tt.rows || len(m[0]) != tt.cols {
+				t.Errorf("got %d rows and %v, want %d x %d", len(m), err, tt.rows, tt.cols)
+			}
+		})
+	}
+}
+
+func TestSamplePolyVectorSeeded(t *testing.T) {
+	r, err := ring.NewRing(256, []uint64{8380417})
+	if err != nil {
+		t.Fatal(err)
+	}
+	newSampler := func(prng sampling.PRNG) ring.Sampler { return ring.NewUniformSampler(prng, r) }
+	seed := []byte("seeded-sample-test")
+	const length = 16
+
+	first, err := SamplePolyVectorSeeded(r, seed, length, newSampler, true, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := SamplePolyVectorSeeded(r, seed, length, newSampler, true, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := SamplePolyVectorSeeded(r, []byte("another-seed"), length, newSampler, true, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(first) != length {
+		t.Fatalf("got %d polynomials, want %d", len(first), length)
+	}
+
+	for i := 0; i < length; i++ {
+		prng, err := sampling.NewKeyedPRNG(seededSampleKey(seed, i))
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := ring.NewUniformSampler(prng, r).ReadNew()
+		r.NTT(want, want)
+		r.MForm(want, want)
+
+		if !r.Equal(first[i], second[i]) {
+			t.Errorf("polynomial %d differs between runs with the same seed", i)
+		}
+		if !r.Equal(first[i], want) {
+			t.Errorf("polynomial %d differs from the serial derivation", i)
+		}
+		if r.Equal(first[i], other[i]) {
+			t.Errorf("polynomial %d is the same under a different seed", i)
+		}
+	}
+
+	for _, length := range []int{0, -1, MaxSampleLength + 1} {
+		v, err := SamplePolyVectorSeeded(r, seed, length, newSampler, false, false)
+		if !errors.Is(err, ErrInvalidSampleLength) || v != nil {
+			t.Errorf("length %d: got %d polynomials and %v, want ErrInvalidSampleLength", length, len(v), err)
+		}
+	}
+}
+
 func TestInitializeMatrix(t *testing.T) {
 	r, err := ring.NewRing(256, []uint64{8380417})
 	if err != nil {
@@ -356,6 +562,97 @@ func TestInitializeMatrix(t *testing.T) {
 	}
 }
 
+func TestBatchModInverse(t *testing.T) {
+	tests := []struct {
+		name string
+		q    uint64
+		vals []uint64
+	}{
+		{name: "empty", q: 8380417, vals: nil},
+		{name: "single", q: 8380417, vals: []uint64{2}},
+		{name: "small modulus", q: 8380417, vals: []uint64{1, 2, 3, 8380416, 12345}},
+		{name: "48-bit modulus", q: 0x1000000004A01, vals: []uint64{7, 0x1000000004A00, 0xABCDEF123456, 99}},
+		{name: "zeros and unreduced", q: 8380417, vals: []uint64{0, 5, 8380417, 8380418, 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := BatchModInverse(tt.vals, tt.q)
+			if len(got) != len(tt.vals) {
+				t.Fatalf("BatchModInverse() returned %d values, want %d", len(got), len(tt.vals))
+			}
+			for i, v := range tt.vals {
+				if want := modInverse(v, tt.q); got[i] != want {
+					t.Errorf("inverse of %d: got %d, want %d", v, got[i], want)
+				}
+				if v%tt.q != 0 && mulMod(v%tt.q, got[i], tt.q) != 1 {
+					t.Errorf("%d * %d != 1 mod %d", v, got[i], tt.q)
+				}
+			}
+		})
+	}
+}
+
+func TestPolyDivNTT(t *testing.T) {
+	const q = 0x1000000004A01
+	a := make([]uint64, 256)
+	for i := range a {
+		a[i] = uint64(i)*0x9E3779B97F4A7C15%q + 1
+	}
+
+	ones, err := PolyDivNTT(a, a, q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, v := range ones {
+		if v != 1 {
+			t.Fatalf("a/a coefficient %d = %d, want 1", i, v)
+		}
+		if got := mulMod(a[i], v, q); got != a[i] {
+			t.Errorf("a*(a/a) coefficient %d = %d, want %d", i, got, a[i])
+		}
+	}
+
+	b := append([]uint64(nil), a...)
+	b[3] = 5
+	quotient, err := PolyDivNTT(a, b, q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := mulMod(quotient[3], 5, q); got != a[3] {
+		t.Errorf("(a/b)*b coefficient 3 = %d, want %d", got, a[3])
+	}
+
+	b[7] = q
+	if _, err := PolyDivNTT(a, b, q); !errors.Is(err, ErrNotInvertible) {
+		t.Errorf("expected ErrNotInvertible, got %v", err)
+	}
+	if _, err := PolyDivNTT(a, b[:10], q); err == nil {
+		t.Error("expected an error for mismatched lengths")
+	}
+}
+
+func BenchmarkBatchModInverse(b *testing.B) {
+	const q = 0x1000000004A01
+	vals := make([]uint64, 256)
+	for i := range vals {
+		vals[i] = uint64(i)*0x9E3779B97F4A7C15%q + 1
+	}
+
+	b.Run("batched", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			BatchModInverse(vals, q)
+		}
+	})
+	b.Run("individual", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, v := range vals {
+				modInverse(v, q)
+			}
+		}
+	})
+}
+
 // Helper functions for testing
 func createTestVector(r *ring.Ring, sampler ring.Sampler, size int) structs.Vector[ring.Poly] {
 	v := make(structs.Vector[ring.Poly], size)